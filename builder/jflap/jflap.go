@@ -0,0 +1,367 @@
+// Package jflap provides functions to translate between JFLAP (.jff) files and machines.
+//
+// JFLAP reads one character at a time, so every symbol in a JFLAP file is a single character.
+// An empty read or pop is lambda, which is machine.Epsilon, except on a Turing machine,
+// where an empty read or write is the blank, turing.Blank.
+// On a Turing machine, "~" is JFLAP's any-symbol rule, which is machine.Wildcard.
+package jflap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+	"github.com/cjcodell1/tint/machine/pushdown"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/ntm"
+	"github.com/cjcodell1/tint/machine/turing/ways/one"
+	"github.com/cjcodell1/tint/machine/turing/ways/two"
+)
+
+// The types of structure in a JFLAP file.
+const (
+	FiniteAutomaton   string = "fa"
+	PushdownAutomaton string = "pda"
+	TuringMachine     string = "turing"
+)
+
+// JFLAP conventions that have no tint equivalent
+const (
+	startStack string = "Z" // JFLAP always starts a PDA with Z on the stack
+	anySymbol  string = "~" // JFLAP's any-symbol rule on a Turing machine
+)
+
+// structure is the root of a JFLAP file.
+// Files older than JFLAP 7 have the states and transitions outside of an automaton.
+type structure struct {
+	XMLName     xml.Name     `xml:"structure"`
+	Type        string       `xml:"type"`
+	Tapes       int          `xml:"tapes,omitempty"`
+	Automaton   automaton    `xml:"automaton"`
+	States      []state      `xml:"state"`
+	Transitions []transition `xml:"transition"`
+}
+
+type automaton struct {
+	States      []state      `xml:"state"`
+	Transitions []transition `xml:"transition"`
+}
+
+type state struct {
+	ID      string    `xml:"id,attr"`
+	Name    string    `xml:"name,attr"`
+	X       float64   `xml:"x"`
+	Y       float64   `xml:"y"`
+	Initial *struct{} `xml:"initial"`
+	Final   *struct{} `xml:"final"`
+}
+
+// transition is any JFLAP transition, only the fields used by the type of structure are set.
+type transition struct {
+	From  string  `xml:"from"`
+	To    string  `xml:"to"`
+	Read  string  `xml:"read"`
+	Write *string `xml:"write"`
+	Move  *string `xml:"move"`
+	Pop   *string `xml:"pop"`
+	Push  *string `xml:"push"`
+}
+
+// Build creates a machine from a JFLAP file.
+func Build(path string, machineType string) (machine.Machine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f, machineType)
+}
+
+// Read creates a machine from the contents of a JFLAP file.
+// A finite automaton can be read as a DFA or an NFA,
+// and a Turing machine can be read as any single-tape Turing machine.
+func Read(r io.Reader, machineType string) (machine.Machine, error) {
+	var s structure
+	err := xml.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return nil, err
+	}
+	s.States = append(s.States, s.Automaton.States...)
+	s.Transitions = append(s.Transitions, s.Automaton.Transitions...)
+
+	switch machineType {
+	case machine.DFA, machine.NFA:
+		if s.Type != FiniteAutomaton {
+			return nil, fmt.Errorf("A %s cannot be built from a JFLAP %s.", machineType, s.Type)
+		}
+		return s.finite(machineType)
+
+	case machine.PDA:
+		if s.Type != PushdownAutomaton {
+			return nil, fmt.Errorf("A %s cannot be built from a JFLAP %s.", machineType, s.Type)
+		}
+		return s.pushdown()
+
+	case machine.ONE_WAY_TM, machine.TWO_WAY_TM, machine.NTM:
+		if s.Type != TuringMachine {
+			return nil, fmt.Errorf("A %s cannot be built from a JFLAP %s.", machineType, s.Type)
+		}
+		if s.Tapes > 1 {
+			return nil, fmt.Errorf("Only single-tape JFLAP Turing machines can be built, this one has %d tapes.", s.Tapes)
+		}
+		return s.turing(machineType)
+
+	default:
+		return nil, fmt.Errorf("A %s cannot be built from a JFLAP file.", machineType)
+	}
+}
+
+// names maps the id of every state to its name.
+// A state without a name is named by its id, and a repeated name is suffixed with its id.
+func (s structure) names() (map[string]string, error) {
+	names := make(map[string]string)
+	taken := make(map[string]bool)
+	for _, st := range s.States {
+		if _, ok := names[st.ID]; ok {
+			return nil, fmt.Errorf("The state id %s is used more than once.", st.ID)
+		}
+		name := st.Name
+		if name == "" {
+			name = "q" + st.ID
+		}
+		if taken[name] {
+			name = name + "_" + st.ID
+		}
+		taken[name] = true
+		names[st.ID] = name
+	}
+	return names, nil
+}
+
+// Returns the start state, the final states, and the transitions with state names instead of ids.
+func (s structure) resolve() (string, []string, []transition, error) {
+	names, err := s.names()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	start := ""
+	finals := []string{}
+	for _, st := range s.States {
+		if st.Initial != nil {
+			if start != "" {
+				return "", nil, nil, fmt.Errorf("There is more than one initial state.")
+			}
+			start = names[st.ID]
+		}
+		if st.Final != nil {
+			finals = append(finals, names[st.ID])
+		}
+	}
+	if start == "" {
+		return "", nil, nil, fmt.Errorf("There is no initial state.")
+	}
+
+	trans := make([]transition, 0, len(s.Transitions))
+	for _, t := range s.Transitions {
+		from, ok := names[t.From]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("A transition is from the unknown state id %s.", t.From)
+		}
+		to, ok := names[t.To]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("A transition is to the unknown state id %s.", t.To)
+		}
+		t.From, t.To = from, to
+		trans = append(trans, t)
+	}
+
+	return start, finals, trans, nil
+}
+
+func (s structure) finite(machineType string) (machine.Machine, error) {
+	start, finals, trans, err := s.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([][]string, 0, len(trans))
+	for _, t := range trans {
+		symbol, err := readSymbol(t.Read, machine.Epsilon)
+		if err != nil {
+			return nil, err
+		}
+		if symbol == machine.Epsilon && machineType == machine.DFA {
+			return nil, fmt.Errorf("The transition from %s to %s reads lambda, which a DFA cannot do.", t.From, t.To)
+		}
+		transitions = append(transitions, []string{t.From, symbol, t.To})
+	}
+
+	if machineType == machine.DFA {
+		return dfa.MakeDFA(transitions, start, finals)
+	}
+	return nfa.MakeNFA(transitions, start, finals)
+}
+
+// A JFLAP PDA starts with Z on the stack. Since JFLAP chooses how a PDA accepts when it is run,
+// a PDA without final states accepts by empty stack, and any other PDA accepts by final state.
+func (s structure) pushdown() (machine.Machine, error) {
+	start, finals, trans, err := s.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([][]string, 0, len(trans))
+	for _, t := range trans {
+		symbol, err := readSymbol(t.Read, machine.Epsilon)
+		if err != nil {
+			return nil, err
+		}
+		pop := ""
+		if t.Pop != nil {
+			pop = *t.Pop
+		}
+		pop, err = readSymbol(pop, machine.Epsilon)
+		if err != nil {
+			return nil, err
+		}
+		push := []string{}
+		if t.Push != nil {
+			for _, r := range strings.TrimSpace(*t.Push) {
+				push = append(push, string(r))
+			}
+		}
+		transitions = append(transitions, []string{t.From, symbol, pop, t.To, strings.Join(push, " ")})
+	}
+
+	return pushdown.MakePDA(transitions, start, startStack, finals, len(finals) == 0)
+}
+
+// JFLAP Turing machines accept as soon as they enter a final state and reject when they halt anywhere else,
+// but tint Turing machines have one accept state, one reject state, and error when they halt anywhere else.
+// So every final state becomes the accept state, and every other state goes to the reject state
+// when none of its transitions can be followed.
+// The reject state is the last state that is not final and has no transitions, which is how Write writes it,
+// or else a new state.
+func (s structure) turing(machineType string) (machine.Machine, error) {
+	start, finals, trans, err := s.resolve()
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.names()
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[string]bool)
+	for _, name := range names {
+		taken[name] = true
+	}
+
+	final := make(map[string]bool)
+	for _, state := range finals {
+		final[state] = true
+	}
+	accept := unique("accept", taken)
+	if len(finals) == 1 {
+		accept = finals[0]
+	}
+	// the states with transitions, and the states with a transition for any symbol, which need no fallback
+	from := make(map[string]bool)
+	fallback := make(map[string]bool)
+	for _, t := range trans {
+		from[t.From] = true
+		if strings.TrimSpace(t.Read) == anySymbol {
+			fallback[t.From] = true
+		}
+	}
+	reject := unique("reject", taken)
+	for _, st := range s.States {
+		if name := names[st.ID]; !final[name] && !from[name] {
+			reject = name
+		}
+	}
+	if final[start] {
+		start = accept
+	}
+
+	transitions := make([][]string, 0, len(trans))
+	for _, t := range trans {
+		if final[t.From] {
+			continue
+		}
+		to := t.To
+		if final[to] {
+			to = accept
+		}
+
+		read, err := readSymbol(t.Read, turing.Blank)
+		if err != nil {
+			return nil, err
+		}
+		write := ""
+		if t.Write != nil {
+			write = *t.Write
+		}
+		write, err = readSymbol(write, turing.Blank)
+		if err != nil {
+			return nil, err
+		}
+		move := turing.Right
+		if t.Move != nil {
+			move = strings.TrimSpace(*t.Move)
+		}
+		transitions = append(transitions, []string{t.From, read, to, write, move})
+	}
+
+	// the fallback transitions, which come last so they are only followed when nothing else can be
+	for _, st := range s.States {
+		if name := names[st.ID]; !final[name] && name != reject && !fallback[name] {
+			transitions = append(transitions, []string{name, machine.Wildcard, reject, machine.Wildcard, turing.Stay})
+		}
+	}
+
+	switch machineType {
+	case machine.ONE_WAY_TM:
+		return one.MakeTuringMachine(transitions, start, accept, reject)
+	case machine.TWO_WAY_TM:
+		return two.MakeTuringMachine(transitions, start, accept, reject)
+	default:
+		return ntm.MakeTuringMachine(transitions, start, accept, reject)
+	}
+}
+
+// readSymbol converts a JFLAP symbol, where empty is the given symbol.
+func readSymbol(symbol string, empty string) (string, error) {
+	symbol = strings.TrimSpace(symbol)
+	switch {
+	case symbol == "":
+		return empty, nil
+	case symbol == anySymbol && empty == turing.Blank:
+		return machine.Wildcard, nil
+	case utf8.RuneCountInString(symbol) > 1:
+		return "", fmt.Errorf("%s is more than one symbol, tint reads one symbol per transition.", strconv.Quote(symbol))
+	default:
+		return symbol, nil
+	}
+}
+
+// unique returns the name, or the name with the first number that makes it unique.
+func unique(name string, taken map[string]bool) string {
+	if !taken[name] {
+		return name
+	}
+	for i := 1; ; i++ {
+		if n := name + strconv.Itoa(i); !taken[n] {
+			return n
+		}
+	}
+}
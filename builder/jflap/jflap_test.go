@@ -0,0 +1,218 @@
+package jflap_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/builder/jflap"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/pushdown"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/ways/two"
+)
+
+type buildTest struct {
+	path    string
+	machine string
+	err     bool
+}
+
+var buildTests = []buildTest{
+	{"examples/dfa.jff", "dfa", false},
+	{"examples/dfa.jff", "nfa", false},
+	{"examples/nfa.jff", "nfa", false},
+	{"examples/nfa.jff", "dfa", true}, // it reads lambda
+	{"examples/pda.jff", "pda", false},
+	{"examples/pda.jff", "nfa", true},
+	{"examples/tm.jff", "one-way-tm", false},
+	{"examples/tm.jff", "two-way-tm", false},
+	{"examples/tm.jff", "nondeterministic-tm", false},
+	{"examples/tm.jff", "multi-tape-tm", true},
+	{"examples/missing.jff", "dfa", true},
+}
+
+func TestBuild(t *testing.T) {
+	for _, tc := range buildTests {
+		_, err := jflap.Build(tc.path, tc.machine)
+		if (err != nil) != tc.err {
+			t.Errorf("Build(%s, %s) == some_machine, %v, expected an error: %t", tc.path, tc.machine, err, tc.err)
+		}
+	}
+}
+
+type runTest struct {
+	path    string
+	machine string
+	input   string
+	accept  bool
+}
+
+var runTests = []runTest{
+	{"examples/dfa.jff", "dfa", "", true},
+	{"examples/dfa.jff", "dfa", "a b a", true},
+	{"examples/dfa.jff", "dfa", "a b", false},
+
+	{"examples/nfa.jff", "nfa", "a b", true},
+	{"examples/nfa.jff", "nfa", "b a", false},
+
+	{"examples/pda.jff", "pda", "a a b b", true},
+	{"examples/pda.jff", "pda", "", true},
+	{"examples/pda.jff", "pda", "a a b", false},
+
+	{"examples/tm.jff", "two-way-tm", "a b b", true},
+	{"examples/tm.jff", "two-way-tm", "b a", false}, // JFLAP halts without a transition, which rejects
+	{"examples/tm.jff", "one-way-tm", "a", true},
+}
+
+// run simulates a machine until it halts, or errors after too many steps.
+func run(m machine.Machine, input string) (bool, error) {
+	conf := m.Start(input)
+	for steps := 0; steps < 1000; steps++ {
+		if m.IsAccept(conf) {
+			return true, nil
+		}
+		if m.IsReject(conf) {
+			return false, nil
+		}
+		var err error
+		conf, err = m.Step(conf)
+		if err != nil {
+			return false, err
+		}
+	}
+	return false, fmt.Errorf("did not halt")
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range runTests {
+		m, err := jflap.Build(tc.path, tc.machine)
+		if err != nil {
+			t.Errorf("Build(%s, %s) == nil, %s", tc.path, tc.machine, err)
+			continue
+		}
+		accept, err := run(m, tc.input)
+		if err != nil || accept != tc.accept {
+			t.Errorf("%s on %q == %t, %v != %t, nil", tc.path, tc.input, accept, err, tc.accept)
+		}
+	}
+}
+
+type writeTest struct {
+	m      machine.Machine
+	name   string
+	inputs []string
+}
+
+var emptyStackPDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"q0", "a", "", "q0", "A"},
+		{"q0", "b", "A", "q0", ""},
+		{"q0", "", "$", "q0", ""},
+	},
+	"q0",
+	"$",
+	[]string{},
+	true)
+
+var wildcardTM, _ = two.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", "q0", "a", turing.Right},
+		{"q0", turing.Blank, "yes", turing.Blank, turing.Stay},
+		{"*", "*", "no", "*", turing.Left},
+	},
+	"q0",
+	"yes",
+	"no")
+
+var writeTests = []writeTest{}
+
+func init() {
+	dfaMachine, _ := jflap.Build("examples/dfa.jff", machine.DFA)
+	nfaMachine, _ := jflap.Build("examples/nfa.jff", machine.NFA)
+	pdaMachine, _ := jflap.Build("examples/pda.jff", machine.PDA)
+
+	writeTests = []writeTest{
+		{dfaMachine, machine.DFA, []string{"", "a", "a b a", "b b"}},
+		{nfaMachine, machine.NFA, []string{"", "b", "a b", "b a"}},
+		{pdaMachine, machine.PDA, []string{"", "a b", "a a b", "a b b"}},
+		{emptyStackPDA, machine.PDA, []string{"", "a b", "a a b"}},
+		{wildcardTM, machine.TWO_WAY_TM, []string{"", "a a", "a b"}},
+	}
+}
+
+// TestWrite writes each machine and reads it back, and checks both agree on every input.
+// The PDA that accepts by empty stack is read back without final states, so it does too.
+func TestWrite(t *testing.T) {
+	for _, tc := range writeTests {
+		var out bytes.Buffer
+		err := jflap.Write(&out, tc.m.(machine.Describer))
+		if err != nil {
+			t.Errorf("Write(%s) == %s", tc.name, err)
+			continue
+		}
+		read, err := jflap.Read(&out, tc.name)
+		if err != nil {
+			t.Errorf("Read(Write(%s)) == nil, %s", tc.name, err)
+			continue
+		}
+		for _, input := range tc.inputs {
+			expect, _ := run(tc.m, input)
+			actual, err := run(read, input)
+			if err != nil || actual != expect {
+				t.Errorf("Read(Write(%s)) on %q == %t, %v != %t, nil", tc.name, input, actual, err, expect)
+			}
+		}
+	}
+}
+
+func TestWriteMultiCharacter(t *testing.T) {
+	m, _ := pushdown.MakePDA([][]string{{"q0", "ab", "", "q0", ""}}, "q0", "", []string{"q0"}, false)
+	var out bytes.Buffer
+	if err := jflap.Write(&out, m.(machine.Describer)); err == nil {
+		t.Errorf("Write(multi-character PDA) == nil, expected an error")
+	}
+}
+
+// roundTrip writes a machine and reads it back.
+func roundTrip(m machine.Machine, name string) (machine.Machine, error) {
+	var out bytes.Buffer
+	err := jflap.Write(&out, m.(machine.Describer))
+	if err != nil {
+		return nil, err
+	}
+	return jflap.Read(&out, name)
+}
+
+// TestWriteTuringRoundTrip checks that a Turing machine read from JFLAP is written and read back
+// with the same states and transitions, so the reject state and its fallbacks are not added again.
+func TestWriteTuringRoundTrip(t *testing.T) {
+	tm, _ := jflap.Build("examples/tm.jff", machine.TWO_WAY_TM)
+	for _, m := range []machine.Machine{tm, wildcardTM} {
+		once, err := roundTrip(m, machine.TWO_WAY_TM)
+		if err != nil {
+			t.Errorf("Read(Write(%v)) == nil, %s", m, err)
+			continue
+		}
+		twice, err := roundTrip(once, machine.TWO_WAY_TM)
+		if err != nil {
+			t.Errorf("Read(Write(%v)) == nil, %s", once, err)
+			continue
+		}
+
+		d1, d2 := once.(machine.Describer), twice.(machine.Describer)
+		if fmt.Sprint(d2.States()) != fmt.Sprint(d1.States()) {
+			t.Errorf("the states written and read back are %v, not %v", d2.States(), d1.States())
+		}
+		if fmt.Sprint(d2.Transitions()) != fmt.Sprint(d1.Transitions()) {
+			t.Errorf("the transitions written and read back are %v, not %v", d2.Transitions(), d1.Transitions())
+		}
+	}
+
+	// a machine read from JFLAP is already stable
+	once, _ := roundTrip(tm, machine.TWO_WAY_TM)
+	d, d1 := tm.(machine.Describer), once.(machine.Describer)
+	if fmt.Sprint(d1.States()) != fmt.Sprint(d.States()) || fmt.Sprint(d1.Transitions()) != fmt.Sprint(d.Transitions()) {
+		t.Errorf("Read(Write(examples/tm.jff)) == %v %v != %v %v", d1.States(), d1.Transitions(), d.States(), d.Transitions())
+	}
+}
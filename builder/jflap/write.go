@@ -0,0 +1,215 @@
+package jflap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// the layout of the states, which JFLAP requires
+const (
+	perRow  int     = 6
+	spacing float64 = 150
+)
+
+// Write writes a DFA, NFA, PDA, or single-tape Turing machine as a JFLAP file.
+// Every symbol must be a single character, since that is all JFLAP can read.
+//
+// A PDA whose stack does not start as Z gets a new initial state that replaces the Z.
+// JFLAP chooses how a PDA accepts when it is run, so accepting by empty stack is not written.
+// The reject state of a Turing machine is written as a state without transitions,
+// which is where JFLAP rejects.
+func Write(w io.Writer, d machine.Describer) error {
+	var s structure
+	var err error
+
+	switch d.Type() {
+	case machine.DFA, machine.NFA:
+		s, err = writeFinite(d)
+	case machine.PDA:
+		p, ok := d.(machine.StackDescriber)
+		if !ok {
+			return fmt.Errorf("This PDA cannot describe its stack.")
+		}
+		s, err = writePushdown(p)
+	case machine.ONE_WAY_TM, machine.TWO_WAY_TM, machine.NTM:
+		s, err = writeTuring(d)
+	default:
+		err = fmt.Errorf("A %s cannot be written as a JFLAP file.", d.Type())
+	}
+	if err != nil {
+		return err
+	}
+
+	out, err := xml.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?><!--Created with tint.-->`+"\n")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(out, '\n'))
+	return err
+}
+
+// newStructure makes a structure with the states laid out in rows, and no transitions.
+func newStructure(structureType string, states []string, start string, finals []string) (structure, map[string]string) {
+	final := make(map[string]bool)
+	for _, state := range finals {
+		final[state] = true
+	}
+
+	ids := make(map[string]string)
+	s := structure{Type: structureType}
+	for i, name := range states {
+		st := state{
+			ID:   strconv.Itoa(i),
+			Name: name,
+			X:    spacing * float64(1+i%perRow),
+			Y:    spacing * float64(1+i/perRow),
+		}
+		if name == start {
+			st.Initial = &struct{}{}
+		}
+		if final[name] {
+			st.Final = &struct{}{}
+		}
+		ids[name] = st.ID
+		s.Automaton.States = append(s.Automaton.States, st)
+	}
+	return s, ids
+}
+
+func writeFinite(d machine.Describer) (structure, error) {
+	s, ids := newStructure(FiniteAutomaton, d.States(), d.StartState(), d.AcceptStates())
+	for _, t := range d.Transitions() {
+		read, err := writeSymbol(t[1], machine.Epsilon)
+		if err != nil {
+			return s, err
+		}
+		s.Automaton.Transitions = append(s.Automaton.Transitions, transition{From: ids[t[0]], To: ids[t[2]], Read: read})
+	}
+	return s, nil
+}
+
+func writePushdown(p machine.StackDescriber) (structure, error) {
+	states := p.States()
+	start := p.StartState()
+
+	// replaces the Z that JFLAP starts the stack with
+	replace := p.StartStack() != startStack
+	if replace {
+		taken := make(map[string]bool)
+		for _, state := range states {
+			taken[state] = true
+		}
+		start = unique("start", taken)
+		states = append([]string{start}, states...)
+	}
+
+	s, ids := newStructure(PushdownAutomaton, states, start, p.AcceptStates())
+	if replace {
+		push, err := writeSymbols(p.StartStack())
+		if err != nil {
+			return s, err
+		}
+		z := startStack
+		s.Automaton.Transitions = append(s.Automaton.Transitions, transition{From: ids[start], To: ids[p.StartState()], Pop: &z, Push: &push})
+	}
+
+	for _, t := range p.Transitions() {
+		read, err := writeSymbol(t[1], machine.Epsilon)
+		if err != nil {
+			return s, err
+		}
+		pop, err := writeSymbol(t[2], machine.Epsilon)
+		if err != nil {
+			return s, err
+		}
+		push, err := writeSymbols(t[4])
+		if err != nil {
+			return s, err
+		}
+		s.Automaton.Transitions = append(s.Automaton.Transitions, transition{From: ids[t[0]], To: ids[t[3]], Read: read, Pop: &pop, Push: &push})
+	}
+	return s, nil
+}
+
+// A transition from any state is written from every state but the accept and reject states,
+// and a transition to any state is written back to the state it is from.
+func writeTuring(d machine.Describer) (structure, error) {
+	s, ids := newStructure(TuringMachine, d.States(), d.StartState(), d.AcceptStates())
+
+	halts := make(map[string]bool)
+	for _, state := range append(d.AcceptStates(), d.RejectStates()...) {
+		halts[state] = true
+	}
+
+	for _, t := range d.Transitions() {
+		read, err := writeSymbol(t[1], turing.Blank)
+		if err != nil {
+			return s, err
+		}
+		write, err := writeSymbol(t[3], turing.Blank)
+		if err != nil {
+			return s, err
+		}
+		move := t[4]
+		if move == turing.NoMove {
+			move = turing.Stay
+		}
+
+		from := []string{t[0]}
+		if t[0] == machine.Wildcard {
+			from = []string{}
+			for _, state := range d.States() {
+				if !halts[state] {
+					from = append(from, state)
+				}
+			}
+		}
+		for _, state := range from {
+			to := t[2]
+			if to == machine.Wildcard {
+				to = state
+			}
+			w, m := write, move
+			s.Automaton.Transitions = append(s.Automaton.Transitions, transition{From: ids[state], To: ids[to], Read: read, Write: &w, Move: &m})
+		}
+	}
+	return s, nil
+}
+
+// writeSymbol converts a symbol to JFLAP, where the given symbol is empty.
+func writeSymbol(symbol string, empty string) (string, error) {
+	switch {
+	case symbol == empty:
+		return "", nil
+	case symbol == machine.Wildcard && empty == turing.Blank:
+		return anySymbol, nil
+	case utf8.RuneCountInString(symbol) > 1:
+		return "", fmt.Errorf("%s is more than one character, which JFLAP cannot read.", strconv.Quote(symbol))
+	default:
+		return symbol, nil
+	}
+}
+
+// writeSymbols converts space-delimited symbols to a JFLAP string.
+func writeSymbols(symbols string) (string, error) {
+	var out strings.Builder
+	for _, symbol := range strings.Fields(symbols) {
+		s, err := writeSymbol(symbol, machine.Epsilon)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(s)
+	}
+	return out.String(), nil
+}
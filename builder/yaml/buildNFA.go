@@ -0,0 +1,23 @@
+package yaml
+
+import (
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+)
+
+// nfaBuilder is the struct to marshal the YAML.
+type nfaBuilder struct {
+	// These must be export, yaml parser requires it.
+	Start       string
+	Accepts     []string `yaml:"accept-states"` // renamed to accept-states
+	Transitions [][]string
+}
+
+func (b nfaBuilder) subBuild() (machine.Machine, error) {
+	n, err := nfa.MakeNFA(b.Transitions, b.Start, b.Accepts)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
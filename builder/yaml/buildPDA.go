@@ -0,0 +1,25 @@
+package yaml
+
+import (
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/pushdown"
+)
+
+// pdaBuilder is the struct to marshal the YAML.
+type pdaBuilder struct {
+	// These must be exported, yaml parser requires it.
+	Start       string
+	StartStack  string   `yaml:"start-stack"`   // renamed to start-stack
+	Accepts     []string `yaml:"accept-states"` // renamed to accept-states
+	EmptyStack  bool     `yaml:"empty-stack"`   // renamed to empty-stack
+	Transitions [][]string
+}
+
+func (b pdaBuilder) subBuild() (machine.Machine, error) {
+	p, err := pushdown.MakePDA(b.Transitions, b.Start, b.StartStack, b.Accepts, b.EmptyStack)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,21 @@
+package yaml
+
+import (
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/regex"
+)
+
+// regexBuilder is the struct to marshal the YAML.
+type regexBuilder struct {
+	// These must be exported, yaml parser requires it.
+	Regex string
+}
+
+func (b regexBuilder) subBuild() (machine.Machine, error) {
+	n, err := regex.Compile(b.Regex)
+	if err != nil {
+		return nil, machine.FieldError{Field: "regex", Err: err}
+	}
+
+	return n, nil
+}
@@ -2,6 +2,8 @@ package yaml
 
 import (
 	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing/multi"
+	"github.com/cjcodell1/tint/machine/turing/ntm"
 	"github.com/cjcodell1/tint/machine/turing/ways/two"
 	"github.com/cjcodell1/tint/machine/turing/ways/one"
 )
@@ -39,3 +41,38 @@ func (b twoWayTmBuilder) subBuild() (machine.Machine, error) {
 
 	return tm, nil
 }
+
+type multiTapeTmBuilder struct {
+	// These must be exported, yaml parser requires it.
+	Tapes       int
+	Start       string
+	Accept      string
+	Reject      string
+	Transitions [][]string
+}
+
+func (b multiTapeTmBuilder) subBuild() (machine.Machine, error) {
+	tm, err := multi.MakeTuringMachine(b.Transitions, b.Tapes, b.Start, b.Accept, b.Reject)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm, nil
+}
+
+type ntmBuilder struct {
+	// These must be exported, yaml parser requires it.
+	Start       string
+	Accept      string
+	Reject      string
+	Transitions [][]string
+}
+
+func (b ntmBuilder) subBuild() (machine.Machine, error) {
+	tm, err := ntm.MakeTuringMachine(b.Transitions, b.Start, b.Accept, b.Reject)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm, nil
+}
@@ -0,0 +1,182 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// required are the keys only one type of machine has, which a file of that type must have.
+var required = map[string]string{
+	machine.MULTI_TAPE_TM: "tapes",
+	machine.REGEX:         "regex",
+}
+
+// typeKey is the key a file can say what type of machine it is with, e.g. type: two-way-tm.
+const typeKey = "type"
+
+// machineTypes are the types of machines that can be built, in the order they are listed in errors.
+var machineTypes = []string{
+	machine.DFA,
+	machine.NFA,
+	machine.PDA,
+	machine.ONE_WAY_TM,
+	machine.TWO_WAY_TM,
+	machine.MULTI_TAPE_TM,
+	machine.NTM,
+	machine.REGEX,
+}
+
+// detect finds the type of machine to build.
+// It is machineType if it is given, or else the type: in the file, or else it is inferred from the file.
+// Errors when the type given and the type: in the file are different,
+// when the file does not look like the type given,
+// and when more than one type of machine fits the file.
+func (d decoder) detect(root *yaml.Node, machineType string) (string, error) {
+	machineType = strings.ToLower(machineType)
+
+	if root != nil {
+		if node := value(root, typeKey); node != nil {
+			fileType := strings.ToLower(node.Value)
+			if node.Kind != yaml.ScalarNode || !isMachineType(fileType) {
+				return "", d.errorAt(node, fmt.Errorf("%s is not a valid machine type, use %s.", node.Value, list(machineTypes)))
+			}
+			if machineType != "" && machineType != fileType {
+				return "", d.errorAt(node, fmt.Errorf("The file is a %s, but it was given as a %s.", fileType, machineType))
+			}
+			return fileType, nil
+		}
+	}
+
+	if machineType != "" {
+		if !isMachineType(machineType) {
+			return "", fmt.Errorf("%s is not a valid machine type.", machineType)
+		}
+		fits := d.fits(root)
+		if root != nil && len(fits) > 0 && !contains(fits, machineType) {
+			return "", d.fileError(fmt.Errorf("The file looks like a %s, not a %s.", list(fits), machineType))
+		}
+		return machineType, nil
+	}
+
+	if root == nil {
+		return "", d.fileError(errors.New("The file is empty, so the type of machine cannot be found."))
+	}
+	fits := d.prefer(root, d.fits(root))
+	switch len(fits) {
+	case 0:
+		return "", d.fileError(errors.New("The type of machine cannot be found from the file, add a type: to the file."))
+	case 1:
+		return fits[0], nil
+	default:
+		return "", d.fileError(fmt.Errorf("The file could be a %s, add a type: to the file.", list(fits)))
+	}
+}
+
+// fits returns the types of machines that have every key in the file, and the keys they require,
+// and whose transitions have as many values as the first transition in the file.
+func (d decoder) fits(root *yaml.Node) []string {
+	if root == nil {
+		return nil
+	}
+
+	arity := -1
+	if trans := value(root, "transitions"); trans != nil && trans.Kind == yaml.SequenceNode && len(trans.Content) > 0 {
+		arity = len(trans.Content[0].Content)
+	}
+
+	fits := []string{}
+	for _, machineType := range machineTypes {
+		b, _ := newBuilder(machineType)
+		names, _ := keys(b)
+		fit := required[machineType] == "" || value(root, required[machineType]) != nil
+		for i := 0; i < len(root.Content); i += 2 {
+			key := root.Content[i].Value
+			if key != typeKey && !contains(names, key) {
+				fit = false
+			}
+		}
+		if fit && arity >= 0 && !hasArity(root, machineType, arity) {
+			fit = false
+		}
+		if fit {
+			fits = append(fits, machineType)
+		}
+	}
+	return fits
+}
+
+// hasArity is true if a transition of the type of machine can have that many values.
+func hasArity(root *yaml.Node, machineType string, arity int) bool {
+	switch machineType {
+	case machine.DFA, machine.NFA:
+		return arity == 3
+	case machine.PDA, machine.ONE_WAY_TM, machine.TWO_WAY_TM, machine.NTM:
+		return arity == 5
+	case machine.MULTI_TAPE_TM:
+		tapes, err := strconv.Atoi(value(root, "tapes").Value)
+		return err != nil || arity == 2+3*tapes
+	default:
+		return false
+	}
+}
+
+// prefer narrows the types of machines that fit a file by whether its transitions are deterministic.
+// Every DFA is also an NFA, so a file with no choice of transition is a DFA, and a file with one is an NFA,
+// and likewise for Turing machines.
+func (d decoder) prefer(root *yaml.Node, fits []string) []string {
+	deterministic := true
+	seen := make(map[string]bool)
+	if trans := value(root, "transitions"); trans != nil {
+		for _, t := range trans.Content {
+			if len(t.Content) < 2 {
+				continue
+			}
+			if len(t.Content) == 3 && t.Content[1].Value == machine.Epsilon {
+				deterministic = false
+			}
+			in := t.Content[0].Value + "\x00" + t.Content[1].Value
+			if seen[in] {
+				deterministic = false
+			}
+			seen[in] = true
+		}
+	}
+
+	preferred := []string{}
+	for _, machineType := range fits {
+		switch machineType {
+		case machine.DFA, machine.ONE_WAY_TM, machine.TWO_WAY_TM:
+			if !deterministic {
+				continue
+			}
+		case machine.NFA, machine.NTM:
+			if deterministic {
+				continue
+			}
+		}
+		preferred = append(preferred, machineType)
+	}
+	if len(preferred) == 0 {
+		return fits
+	}
+	return preferred
+}
+
+func isMachineType(machineType string) bool {
+	return contains(machineTypes, machineType)
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package yaml
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// Write writes a DFA or NFA as a YAML file that Build can read.
+func Write(w io.Writer, d machine.Describer) error {
+	switch d.Type() {
+	case machine.DFA, machine.NFA:
+	default:
+		return fmt.Errorf("A %s cannot be written as a YAML file.", d.Type())
+	}
+
+	var out strings.Builder
+	out.WriteString("---\n")
+	out.WriteString("start: " + quote(d.StartState()) + "\n")
+	out.WriteString("accept-states: " + flow(d.AcceptStates()) + "\n")
+	if trans := d.Transitions(); len(trans) == 0 {
+		out.WriteString("transitions: []\n")
+	} else {
+		out.WriteString("transitions:\n")
+		for _, t := range trans {
+			out.WriteString("  - " + flow(t) + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// flow writes a list in the flow style, like [a, b, c].
+func flow(list []string) string {
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = quote(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// quote double quotes a string if YAML would read it as something else.
+func quote(s string) string {
+	if s == "" || s == "~" || strings.EqualFold(s, "null") || s != strings.TrimSpace(s) || strings.ContainsAny(s, ":,[]{}#&*!|>'\"%@`\\") || strings.ContainsAny(s[:1], "-?") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -2,9 +2,14 @@
 package yaml
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 
 	"github.com/cjcodell1/tint/file"
 	"github.com/cjcodell1/tint/machine"
@@ -14,11 +19,39 @@ type builder interface {
 	subBuild() (machine.Machine, error)
 }
 
-type strawman struct{}
+// Error is a mistake in a YAML file, with where it is in the file.
+type Error struct {
+	Path   string
+	Line   int    // counted from 1, or 0 if the mistake is not on one line
+	Column int    // counted from 1, or 0 if only the line is known
+	Entry  string // the line the mistake is on, or "" if there is none
+	Err    error
+}
 
-func (s strawman) subBuild() (machine.Machine, error) { return nil, nil }
+// Error writes the error like path:line:column: message, followed by the line the mistake is on.
+func (e *Error) Error() string {
+	pos := e.Path
+	if e.Line > 0 {
+		pos += ":" + strconv.Itoa(e.Line)
+	}
+	if e.Column > 0 {
+		pos += ":" + strconv.Itoa(e.Column)
+	}
+	msg := fmt.Sprintf("%s: %s", pos, e.Err)
+	if e.Entry != "" {
+		msg += "\n\t" + e.Entry
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
 
 // Build creates a Turing machine from a YAML file.
+// The type of machine is machineType, or else the type: in the file,
+// or else it is inferred from the keys and transitions in the file.
+// Errors are an *Error that says where in the file the mistake is.
 func Build(configPath string, machineType string) (machine.Machine, error) {
 
 	var config string
@@ -30,65 +63,265 @@ func Build(configPath string, machineType string) (machine.Machine, error) {
 		return nil, err
 	}
 
+	d := decoder{configPath, strings.Split(config, "\n"), machineType}
+
 	// Unmarshal the YAML
-	// TODO figure out how to change the builder in the switch like this
-	// 		so I don't have to copy/paste the Unmarshalling and subBuilding each time
-	//var b builder
+	var doc yaml.Node
+	err = yaml.Unmarshal([]byte(config), &doc)
+	if err != nil {
+		return nil, d.yamlError(err)
+	}
+	var root *yaml.Node
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, d.errorAt(root, errors.New("The file must be keys and values, like start: q0."))
+		}
+	}
 
-	switch machineType {
-	case machine.DFA:
-		//b, ok : = b.(dfaBuilder)
-		var b dfaBuilder
+	d.machineType, err = d.detect(root, machineType)
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBuilder(d.machineType)
+	if err != nil {
+		return nil, err
+	}
 
-		err = yaml.Unmarshal([]byte(config), &b)
+	if root != nil {
+		err = d.check(root, b)
 		if err != nil {
 			return nil, err
 		}
-
-		// Build the machine
-		machine, err := b.subBuild()
+		err = root.Decode(b)
 		if err != nil {
-			return nil, err
+			return nil, d.yamlError(err)
 		}
+	}
+
+	// Build the machine
+	m, err := b.subBuild()
+	if err != nil {
+		return nil, d.buildError(root, err)
+	}
 
-		return machine, nil
+	return m, nil
+}
 
+// newBuilder returns the builder to decode a type of machine into.
+func newBuilder(machineType string) (builder, error) {
+	switch machineType {
+	case machine.DFA:
+		return &dfaBuilder{}, nil
+	case machine.NFA:
+		return &nfaBuilder{}, nil
+	case machine.PDA:
+		return &pdaBuilder{}, nil
 	case machine.ONE_WAY_TM:
-		//b, ok := b.(oneWayTmBuilder)
-		var b oneWayTmBuilder
+		return &oneWayTmBuilder{}, nil
+	case machine.TWO_WAY_TM:
+		return &twoWayTmBuilder{}, nil
+	case machine.MULTI_TAPE_TM:
+		return &multiTapeTmBuilder{}, nil
+	case machine.REGEX:
+		return &regexBuilder{}, nil
+	case machine.NTM:
+		return &ntmBuilder{}, nil
+	default:
+		return nil, fmt.Errorf("%s is not a valid machine type.", machineType)
+	}
+}
 
-		err = yaml.Unmarshal([]byte(config), &b)
-		if err != nil {
-			return nil, err
+// decoder checks the shape of a YAML file before it is decoded, and finds where errors are in it.
+type decoder struct {
+	path        string
+	lines       []string
+	machineType string
+}
+
+// errorAt makes an error at the line and column of a node.
+func (d decoder) errorAt(node *yaml.Node, err error) *Error {
+	entry := ""
+	if node.Line > 0 && node.Line <= len(d.lines) {
+		entry = strings.TrimSpace(d.lines[node.Line-1])
+	}
+	return &Error{d.path, node.Line, node.Column, entry, err}
+}
+
+// fileError makes an error about the whole file.
+func (d decoder) fileError(err error) *Error {
+	return &Error{d.path, 0, 0, "", err}
+}
+
+// yamlLine matches the errors of the YAML parser, like "yaml: line 3: did not find expected key".
+var yamlLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// parserProblems are the errors of the YAML parser, as opposed to its scanner.
+// The parser counts lines from 0 when it reports them, so they are one line too early.
+var parserProblems = map[string]bool{
+	"did not find expected <stream-start>":   true,
+	"did not find expected <document start>": true,
+	"did not find expected node content":     true,
+	"did not find expected key":              true,
+	"did not find expected '-' indicator":    true,
+	"did not find expected ',' or ']'":       true,
+	"did not find expected ',' or '}'":       true,
+	"found duplicate %YAML directive":        true,
+	"found duplicate %TAG directive":         true,
+	"found incompatible YAML document":       true,
+	"found undefined tag handle":             true,
+}
+
+// yamlError makes an error from an error of the YAML parser, which only knows the line.
+func (d decoder) yamlError(err error) *Error {
+	msg := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+	msg = strings.TrimPrefix(msg, "yaml: ")
+
+	line := 0
+	if match := yamlLine.FindStringSubmatch(msg); match != nil {
+		line, _ = strconv.Atoi(match[1])
+		msg = match[2]
+		if parserProblems[msg] {
+			line++
 		}
+	}
+	entry := ""
+	if line > 0 && line <= len(d.lines) {
+		entry = strings.TrimSpace(d.lines[line-1])
+	}
+	return &Error{d.path, line, 0, entry, errors.New(sentence(msg))}
+}
 
-		// Build the machine
-		machine, err := b.subBuild()
-		if err != nil {
-			return nil, err
+// sentence capitalizes a message of the YAML parser and ends it with a period.
+func sentence(msg string) string {
+	if msg == "" {
+		return msg
+	}
+	return strings.ToUpper(msg[:1]) + strings.TrimSuffix(msg[1:], ".") + "."
+}
+
+// buildError makes an error from an error building the machine,
+// at the transition or value it is about if the machine says which one.
+func (d decoder) buildError(root *yaml.Node, err error) error {
+	if root == nil {
+		return d.fileError(err)
+	}
+
+	var transErr machine.TransitionError
+	if errors.As(err, &transErr) {
+		trans := value(root, "transitions")
+		if trans != nil && transErr.Index < len(trans.Content) {
+			return d.errorAt(trans.Content[transErr.Index], err)
 		}
+	}
 
-		return machine, nil
+	var fieldErr machine.FieldError
+	if errors.As(err, &fieldErr) {
+		if node := value(root, fieldErr.Field); node != nil {
+			return d.errorAt(node, err)
+		}
+	}
 
-	case machine.TWO_WAY_TM:
-		//b, ok := b.(twoWayTmBuilder)
-		var b twoWayTmBuilder
+	return d.fileError(err)
+}
+
+// value finds the value of a key in a mapping, or nil if the key is not there.
+func value(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
 
-		err = yaml.Unmarshal([]byte(config), &b)
+// keys returns the keys of the fields of a builder, in the order they are declared.
+func keys(b builder) ([]string, []reflect.Type) {
+	t := reflect.TypeOf(b).Elem()
+	names := []string{}
+	types := []reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		names = append(names, name)
+		types = append(types, f.Type)
+	}
+	return names, types
+}
+
+// check checks that the file is a mapping of the keys of the builder,
+// other than type:, and that each value is a single value, a list of values, or a list of lists of values as the builder needs.
+func (d decoder) check(root *yaml.Node, b builder) error {
+	names, types := keys(b)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		if key.Value == typeKey {
+			continue // already checked by detect
+		}
+
+		t := reflect.Type(nil)
+		for j, name := range names {
+			if key.Value == name {
+				t = types[j]
+			}
+		}
+		if t == nil {
+			return d.errorAt(key, fmt.Errorf("%s is not a key of a %s, use %s.", key.Value, d.machineType, list(append([]string{typeKey}, names...))))
+		}
+
+		var err error
+		switch {
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Slice:
+			err = d.checkList(val, key.Value, func(entry *yaml.Node) error {
+				return d.checkList(entry, "Each of the "+key.Value, d.checkScalar)
+			})
+		case t.Kind() == reflect.Slice:
+			err = d.checkList(val, key.Value, d.checkScalar)
+		default:
+			if val.Kind != yaml.ScalarNode {
+				err = d.errorAt(val, fmt.Errorf("%s must be a single value.", key.Value))
+			}
+		}
 		if err != nil {
-			return nil, err
+			return err
 		}
+	}
+	return nil
+}
 
-		// Build the machine
-		machine, err := b.subBuild()
+// checkList checks that a node is a list, and checks each of its entries.
+func (d decoder) checkList(node *yaml.Node, name string, checkEntry func(*yaml.Node) error) error {
+	if node.Kind != yaml.SequenceNode {
+		return d.errorAt(node, fmt.Errorf("%s must be a list, like [a, b].", name))
+	}
+	for _, entry := range node.Content {
+		err := checkEntry(entry)
 		if err != nil {
-			return nil, err
+			return err
 		}
+	}
+	return nil
+}
 
-		return machine, nil
+// checkScalar checks that a node in a list is a single value.
+func (d decoder) checkScalar(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return d.errorAt(node, errors.New("The values in a list must be single values, not lists or keys and values (quote values with special characters)."))
+	}
+	return nil
+}
 
-	default:
-		err = fmt.Errorf("%s is not a valid machine type.", machineType)
-		return nil, err
+// list writes names like a, b, or c.
+func list(names []string) string {
+	if len(names) < 3 {
+		return strings.Join(names, " or ")
 	}
+	return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
 }
@@ -1,9 +1,16 @@
 package yaml_test
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cjcodell1/tint/builder/yaml"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
 )
 
 type buildTest struct {
@@ -21,6 +28,20 @@ var buildTests = []buildTest{
 	{"dfa_examples/config1.yaml", "dfa", nil},
 	{"dfa_examples/config2.yaml", "dfa", nil},
 	{"dfa_examples/config3.yaml", "dfa", nil},
+	{"dfa_examples/config4.yaml", "dfa", nil},
+
+	{"nfa_examples/config1.yaml", "nfa", nil},
+	{"nfa_examples/config2.yaml", "nfa", nil},
+
+	{"pda_examples/config1.yaml", "pda", nil},
+	{"pda_examples/config2.yaml", "pda", nil},
+
+	{"multi_tm_examples/config1.yaml", "multi-tape-tm", nil},
+
+	{"ntm_examples/config1.yaml", "nondeterministic-tm", nil},
+
+	{"regex_examples/config1.yaml", "regex", nil},
+	{"regex_examples/config2.yaml", "regex", nil},
 }
 
 func TestBuild(t *testing.T) {
@@ -31,3 +52,180 @@ func TestBuild(t *testing.T) {
 		}
 	}
 }
+
+var writeTests = []buildTest{
+	{"dfa_examples/config1.yaml", "dfa", nil},
+	{"dfa_examples/config2.yaml", "dfa", nil},
+	{"dfa_examples/config3.yaml", "dfa", nil},
+	{"dfa_examples/config4.yaml", "dfa", nil},
+
+	{"nfa_examples/config1.yaml", "nfa", nil},
+	{"nfa_examples/config2.yaml", "nfa", nil},
+}
+
+// TestWrite writes each machine and builds it again, and checks nothing changed.
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	for i, tc := range writeTests {
+		m, err := yaml.Build(tc.path, tc.machine)
+		if err != nil {
+			t.Errorf("Build(%s, %s) == nil, %s", tc.path, tc.machine, err)
+			continue
+		}
+		d := m.(machine.Describer)
+
+		path := filepath.Join(dir, fmt.Sprintf("config%d.yaml", i))
+		var out bytes.Buffer
+		err = yaml.Write(&out, d)
+		if err == nil {
+			err = os.WriteFile(path, out.Bytes(), 0644)
+		}
+		if err != nil {
+			t.Errorf("Write(%s) == %s", tc.path, err)
+			continue
+		}
+
+		written, err := yaml.Build(path, tc.machine)
+		if err != nil {
+			t.Errorf("Build(Write(%s)) == nil, %s\n%s", tc.path, err, out.String())
+			continue
+		}
+		w := written.(machine.Describer)
+		expect := fmt.Sprint(d.StartState(), " ", d.AcceptStates(), " ", d.Transitions())
+		actual := fmt.Sprint(w.StartState(), " ", w.AcceptStates(), " ", w.Transitions())
+		if actual != expect {
+			t.Errorf("Build(Write(%s)) == %s != %s", tc.path, actual, expect)
+		}
+	}
+}
+
+func TestWriteQuotes(t *testing.T) {
+	m, _ := dfa.MakeDFA([][]string{{"a: b", "*", "null"}, {"-x", "", "'"}}, "[", []string{"#", "~"})
+	var out bytes.Buffer
+	yaml.Write(&out, m.(machine.Describer))
+	expect := `---
+start: "["
+accept-states: ["#", "~"]
+transitions:
+  - ["a: b", "*", "null"]
+  - ["-x", "", "'"]
+`
+	if out.String() != expect {
+		t.Errorf("Write(quoted DFA) == %s != %s", out.String(), expect)
+	}
+}
+
+type errorTest struct {
+	config  string
+	machine string
+	err     string
+}
+
+var errorTests = []errorTest{
+	{"start: q0\naccept: qa\nreject: qr\nacept: qa\n", "one-way-tm",
+		"config.yaml:4:1: acept is not a key of a one-way-tm, use type, start, accept, reject, or transitions.\n\tacept: qa"},
+	{"start: q0\naccept: qa\nreject: qr\ntransitions:\n  - [q0, a, q0, a, R]\n  - [q0, b, q0, b, X]\n", "one-way-tm",
+		"config.yaml:6:5: transition 2: X is not a legal move, use R, L, or S.\n\t- [q0, b, q0, b, X]"},
+	{"start: q0\naccept: qa\nreject: qa\n", "two-way-tm",
+		"config.yaml:3:9: reject: qa cannot be both the accept state and the reject state.\n\treject: qa"},
+	{"tapes: 0\nstart: q0\naccept: qa\nreject: qr\n", "multi-tape-tm",
+		"config.yaml:1:8: tapes: 0 is not a legal number of tapes, there must be at least 1.\n\ttapes: 0"},
+	{"start: q0\naccept-states: [q1]\ntransitions:\n  - [q0, a]\n", "dfa",
+		"config.yaml:4:5: transition 1: A transition must have 3 values, not 2.\n\t- [q0, a]"},
+	{"start: q0\naccept-states: [q1]\ntransitions:\n  - [q0, a, q1]\n\t- [q0, b, q1]\n", "dfa",
+		"config.yaml:5: Found character that cannot start any token.\n\t- [q0, b, q1]"},
+	{"start: q0\naccept-states: [q1]\ntransitions:\n  - [q0, a, q1]\n  - [q1, a, q1]\n- [q1, b, q1]\n", "dfa",
+		"config.yaml:6: Did not find expected key.\n\t- [q1, b, q1]"},
+	{"start: q0\naccept-states: [q1]\ntransitions:\n  - [q0, a, q1]\n  - [q1, a, q1]\n  - [q1, b, q1\n", "dfa",
+		"config.yaml:6: Did not find expected ',' or ']'.\n\t- [q1, b, q1"},
+	{"start: q0\naccept-states: [q1\ntransitions:\n  - [q0, a, q1]\n", "dfa",
+		"config.yaml:2: Did not find expected ',' or ']'.\n\taccept-states: [q1"},
+	{"start: q0\naccept-states: [q1]\nx: @a\n", "dfa",
+		"config.yaml:3: Found character that cannot start any token.\n\tx: @a"},
+	{"start: q0\naccept-states: q1\n", "nfa",
+		"config.yaml:2:16: accept-states must be a list, like [a, b].\n\taccept-states: q1"},
+	{"start: q0\naccept-states: [q1]\ntransitions:\n  - [q0, a: b, q1]\n", "nfa",
+		"config.yaml:4:10: The values in a list must be single values, not lists or keys and values (quote values with special characters).\n\t- [q0, a: b, q1]"},
+	{"regex: a(b\n", "regex",
+		"config.yaml:1:8: regex: There is a ( without a matching ).\n\tregex: a(b"},
+}
+
+// TestBuildErrors checks that errors say where in the file the mistake is.
+func TestBuildErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range errorTests {
+		path := filepath.Join(dir, "config.yaml")
+		err := os.WriteFile(path, []byte(tc.config), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = yaml.Build(path, tc.machine)
+		if err == nil {
+			t.Errorf("Build(%q, %s) == some_machine, nil", tc.config, tc.machine)
+			continue
+		}
+		actual := strings.TrimPrefix(err.Error(), dir+string(filepath.Separator))
+		if actual != tc.err {
+			t.Errorf("Build(%q, %s) == nil, %s != nil, %s", tc.config, tc.machine, actual, tc.err)
+		}
+	}
+}
+
+var detectTests = []buildTest{
+	{"dfa_examples/config1.yaml", "dfa", nil},
+	{"dfa_examples/config4.yaml", "dfa", nil},
+	{"nfa_examples/config1.yaml", "nfa", nil},
+	{"nfa_examples/config2.yaml", "nfa", nil},
+	{"pda_examples/config1.yaml", "pda", nil},
+	{"multi_tm_examples/config1.yaml", "multi-tape-tm", nil},
+	{"ntm_examples/config1.yaml", "nondeterministic-tm", nil},
+	{"regex_examples/config1.yaml", "nfa", nil}, // a regex is compiled to an NFA
+}
+
+// TestDetect builds each machine without giving its type.
+func TestDetect(t *testing.T) {
+	for _, tc := range detectTests {
+		m, err := yaml.Build(tc.path, "")
+		if err != nil {
+			t.Errorf("Build(%s, \"\") == nil, %s", tc.path, err)
+			continue
+		}
+		if actual := m.(machine.Describer).Type(); actual != tc.machine {
+			t.Errorf("Build(%s, \"\").Type() == %s != %s", tc.path, actual, tc.machine)
+		}
+	}
+}
+
+var detectErrorTests = []errorTest{
+	{"start: q0\naccept: qa\nreject: qr\ntransitions:\n  - [q0, a, qa, a, R]\n", "",
+		"config.yaml: The file could be a one-way-tm or two-way-tm, add a type: to the file."},
+	{"start: q0\naccept: qa\nreject: qr\ntransitions:\n  - [q0, a, qa, a, R]\n", "dfa",
+		"config.yaml: The file looks like a one-way-tm, two-way-tm, or nondeterministic-tm, not a dfa."},
+	{"type: two-way-tm\nstart: q0\naccept: qa\nreject: qr\n", "one-way-tm",
+		"config.yaml:1:7: The file is a two-way-tm, but it was given as a one-way-tm.\n\ttype: two-way-tm"},
+	{"type: tm\nstart: q0\n", "",
+		"config.yaml:1:7: tm is not a valid machine type, use dfa, nfa, pda, one-way-tm, two-way-tm, multi-tape-tm, nondeterministic-tm, or regex.\n\ttype: tm"},
+	{"", "",
+		"config.yaml: The file is empty, so the type of machine cannot be found."},
+}
+
+// TestDetectErrors checks the errors when the type of machine cannot be found, or is not the type given.
+func TestDetectErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range detectErrorTests {
+		path := filepath.Join(dir, "config.yaml")
+		err := os.WriteFile(path, []byte(tc.config), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = yaml.Build(path, tc.machine)
+		if err == nil {
+			t.Errorf("Build(%q, %q) == some_machine, nil", tc.config, tc.machine)
+			continue
+		}
+		actual := strings.TrimPrefix(err.Error(), dir+string(filepath.Separator))
+		if actual != tc.err {
+			t.Errorf("Build(%q, %q) == nil, %s != nil, %s", tc.config, tc.machine, actual, tc.err)
+		}
+	}
+}
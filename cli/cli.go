@@ -2,20 +2,26 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/cjcodell1/tint/builder/yaml"
 	"github.com/cjcodell1/tint/file"
 	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/sim"
 )
 
 var (
-	verboseFlag bool   // prints out the step-by-step simulation
-	testFlag    bool   // use a single test instead of a file of tests
-	machineFlag string // denotes what type of machine is specified
+	verboseFlag bool          // prints out the step-by-step simulation
+	testFlag    bool          // use a single test instead of a file of tests
+	machineFlag string        // denotes what type of machine is specified
+	pathFlag    bool          // prints out the accepting path of a nondeterministic machine
+	stepsFlag   int           // the most steps to simulate a test for, 0 for no limit
+	timeoutFlag time.Duration // the longest time to simulate a test for, 0 for no limit
+	formatFlag  string        // the format to report the results in: text, json, or jsonl
 )
 
 func init() {
@@ -42,9 +48,43 @@ func init() {
 	flag.StringVar(&machineFlag, "m", "", usage+" (short-hand)")
 }
 
+func init() {
+	const (
+		usage = "print out the accepting computation path of a nondeterministic machine"
+	)
+	flag.BoolVar(&pathFlag, "path", false, usage)
+	flag.BoolVar(&pathFlag, "p", false, usage+" (short-hand)")
+}
+
+func init() {
+	const (
+		usage = "stop simulating a test after this many steps (0 for no limit)"
+	)
+	flag.IntVar(&stepsFlag, "max-steps", 0, usage)
+}
+
+func init() {
+	const (
+		usage = "stop simulating a test after this long, e.g. 10s (0 for no limit)"
+	)
+	flag.DurationVar(&timeoutFlag, "timeout", 0, usage)
+}
+
+func init() {
+	const (
+		usage = "report the results as text, json, jsonl (a JSON object on each line, as each test finishes), or junit (JUnit XML)"
+	)
+	flag.StringVar(&formatFlag, "format", textFormat, usage)
+}
+
 // Run starts the program by building the Turing machine and
-// simulating it with test(s).
+// simulating it with test(s), or by running the command given as the first argument.
 func Run() {
+	// Runs a command instead, if one is given.
+	if runCommand(os.Args[1:]) {
+		return
+	}
+
 	// Ensures the flags are parsed.
 	if !flag.Parsed() {
 		flag.Parse()
@@ -57,78 +97,112 @@ func Run() {
 		os.Exit(1)
 	}
 
-	// Ensures the machine flag was set.
-	if machineFlag == "" {
+	// Ensures the format is one that can be reported.
+	newReporter, ok := reporters[formatFlag]
+	if !ok {
 		flag.PrintDefaults()
-		fmt.Println("Please provide the type of machine the file specifies.")
+		fmt.Printf("%s is not a format, use text, json, jsonl, or junit.\n", formatFlag)
 		os.Exit(1)
 	}
+	mPath := flag.Arg(0)
+	r := newReporter(os.Stdout, mPath)
+
 	// normalizes the machine flag
 	machineFlag = strings.ToLower(machineFlag)
 	var m machine.Machine
-	var tests []string
+	var tests []file.Test
 
 	// Builds the Turing machine from the first non-flag argument.
-	mPath := flag.Arg(0)
-	m, err := yaml.Build(mPath, machineFlag)
+	m, err := build(mPath, machineFlag)
 	if err != nil {
-		flag.PrintDefaults()
-		fmt.Println("There was an error building your machine.")
-		fmt.Println(err)
+		r.fatal("There was an error building your machine.", err)
 		os.Exit(1)
 	}
 
 	// Builds the slice of tests used for testing from the second non-flag argument.
 	if testFlag {
-		test := flag.Arg(1)
+		test := file.ParseTest(flag.Arg(1))
 		tests = append(tests, test)
 	} else {
 		testsPath := flag.Arg(1)
-		tests, err = file.ReadLines(testsPath)
+		tests, err = file.ReadTests(testsPath)
 		if err != nil {
-			flag.PrintDefaults()
-			fmt.Println(err)
+			if formatFlag == textFormat {
+				flag.PrintDefaults()
+			}
+			r.fatal("There was an error reading your tests.", err)
 			os.Exit(1)
 		}
 	}
 
 	// Simulate the test
-	totalAccept := 0
-	totalReject := 0
-	totalError := 0
-	for _, input := range tests {
-		fmt.Printf("Simulating with \"%s\".\n", input)
-
-		conf := m.Start(input)
-		for {
-			// print verbosely
-			if verboseFlag {
-				fmt.Println(conf.Print())
-			}
-
-			// check if accept or reject and break
-			if m.IsAccept(conf) {
-				totalAccept += 1
-				fmt.Println("Accepted.\n")
-				break
-			} else if m.IsReject(conf) {
-				totalReject += 1
-				fmt.Println("Rejected.\n")
-				break
-			}
+	var total summary
+	for _, test := range tests {
+		r.begin(test)
+		// only traces with -v, since a trace copies every configuration
+		var trace func(int, machine.Configuration)
+		if verboseFlag {
+			trace = r.step
+		}
+		res := simulate(m, test, trace)
+		switch res.Outcome {
+		case sim.Accept:
+			total.Accepted += 1
+		case sim.Reject:
+			total.Rejected += 1
+		case sim.Error:
+			total.Errors += 1
+		default:
+			total.DidNotHalt += 1
+		}
 
-			// step
-			conf, err = m.Step(conf)
-			if err != nil {
-				fmt.Println("ERROR! Please see below:")
-				fmt.Println(err)
-				fmt.Println("Skipping this test.\n")
-				totalError += 1
-				break
+		// check the outcome if the test expects one
+		if test.Expect != "" {
+			if res.passed() {
+				total.Passed += 1
+			} else {
+				total.Failed += 1
 			}
 		}
+		r.result(res)
+	}
+	r.end(total)
+
+	if total.Failed != 0 {
+		os.Exit(1)
+	}
+}
+
+// result is what happened when a machine was simulated on a test.
+type result struct {
+	sim.Result
+	test file.Test
+	path []machine.Configuration // the accepting path, when -p is set and the machine is nondeterministic
+}
+
+// passed is true if the test expects the outcome.
+func (res result) passed() bool {
+	return res.Outcome == res.test.Expect
+}
+
+// simulate runs the machine on the test until it halts, errors, or is stopped by -max-steps or -timeout,
+// calling trace with each configuration if it is not nil, and returns what happened.
+func simulate(m machine.Machine, test file.Test, trace func(int, machine.Configuration)) result {
+	ctx := context.Background()
+	if timeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeoutFlag)
+		defer cancel()
+	}
+
+	res := result{test: test}
+	res.Result = sim.Run(ctx, m, test.Input, sim.Options{
+		MaxSteps:    stepsFlag,
+		DetectLoops: true,
+		Trace:       trace,
+	})
+	if nondeterministic, ok := m.(machine.Nondeterministic); ok && pathFlag && res.Outcome == sim.Accept {
+		res.path = nondeterministic.AcceptingPath(res.Final)
 	}
-	fmt.Printf("%d accepted.\n", totalAccept)
-	fmt.Printf("%d rejected.\n", totalReject)
-	fmt.Printf("%d errors.\n", totalError)
+	return res
 }
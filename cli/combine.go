@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cjcodell1/tint/builder/yaml"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+// operations are the binary operations combine can do, by name.
+var operations = map[string]func(machine.Machine, machine.Machine) (machine.Machine, error){
+	"union":      dfa.Union,
+	"intersect":  dfa.Intersect,
+	"difference": dfa.Difference,
+}
+
+// combineCommand writes the union, intersection, or difference of two DFAs,
+// or the complement of one DFA, to stdout as YAML.
+//
+//	tint combine -op union|intersect|difference [-minimize] MACHINE_FILE MACHINE_FILE
+//	tint combine -op complement [-minimize] MACHINE_FILE
+func combineCommand(args []string) {
+	flags := flag.NewFlagSet("combine", flag.ExitOnError)
+	var op string
+	var minimize bool
+	flags.StringVar(&op, "op", "", "the operation: union, intersect, difference, or complement")
+	flags.BoolVar(&minimize, "minimize", false, "minimize the DFA that is written")
+	flags.Parse(args)
+
+	var m machine.Machine
+	var err error
+	if op == "complement" {
+		// Ensures there is one non-flag argument.
+		if flags.NArg() != 1 {
+			flags.PrintDefaults()
+			fmt.Println("Please provide the DFA to complement.")
+			os.Exit(1)
+		}
+		m, err = dfa.Complement(buildMachine(flags.Arg(0), machine.DFA))
+	} else {
+		operation, ok := operations[op]
+		if !ok {
+			flags.PrintDefaults()
+			fmt.Println("Please provide the operation to combine the DFAs with.")
+			os.Exit(1)
+		}
+		// Ensures there are two non-flag arguments.
+		if flags.NArg() != 2 {
+			flags.PrintDefaults()
+			fmt.Println("Please provide the two DFAs to combine.")
+			os.Exit(1)
+		}
+		m, err = operation(buildMachine(flags.Arg(0), machine.DFA), buildMachine(flags.Arg(1), machine.DFA))
+	}
+	if err == nil && minimize {
+		m, _, err = dfa.Minimize(m)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	err = yaml.Write(os.Stdout, describe(m))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cjcodell1/tint/builder/jflap"
+	"github.com/cjcodell1/tint/builder/yaml"
+	"github.com/cjcodell1/tint/machine"
+)
+
+// commands are run by name, as the first argument, in place of simulating a machine.
+var commands = map[string]func(args []string){
+	"combine":     combineCommand,
+	"compile":     compileCommand,
+	"debug":       debugCommand,
+	"determinize": determinizeCommand,
+	"equivalent":  equivalentCommand,
+	"export":      exportCommand,
+	"minimize":    minimizeCommand,
+	"regex":       regexCommand,
+	"tui":         tuiCommand,
+	"validate":    validateCommand,
+}
+
+// runCommand runs the command named by the first argument, if there is one.
+// Returns false if the first argument is not a command.
+func runCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		return false
+	}
+	command(args[1:])
+	return true
+}
+
+// buildMachine builds the machine of the given type from the file at path,
+// or of the type the file specifies if machineType is "".
+// Exits with the error if the machine cannot be built.
+func buildMachine(path string, machineType string) machine.Machine {
+	m, err := build(path, strings.ToLower(machineType))
+	if err != nil {
+		fmt.Println("There was an error building your machine.")
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return m
+}
+
+// build builds the machine of the given type from a JFLAP file if the path ends in .jff,
+// otherwise from a YAML file.
+func build(path string, machineType string) (machine.Machine, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".jff" {
+		if machineType == "" {
+			return nil, errors.New("Please provide the type of machine the JFLAP file specifies.")
+		}
+		return jflap.Build(path, machineType)
+	}
+	return yaml.Build(path, machineType)
+}
+
+// describe gets the description of a machine.
+// Exits if the machine cannot describe itself.
+func describe(m machine.Machine) machine.Describer {
+	d, ok := m.(machine.Describer)
+	if !ok {
+		fmt.Println("This type of machine cannot be described.")
+		os.Exit(1)
+	}
+	return d
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cjcodell1/tint/builder/yaml"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/regex"
+)
+
+// compileCommand writes the NFA, or minimal DFA, of a regular expression to stdout as YAML.
+// The regular expression is read from a regex file, or given with -e.
+//
+//	tint compile [-dfa] REGEX_FILE
+//	tint compile [-dfa] -e REGEX
+func compileCommand(args []string) {
+	flags := flag.NewFlagSet("compile", flag.ExitOnError)
+	var toDFA bool
+	var expr string
+	flags.BoolVar(&toDFA, "dfa", false, "compile to the minimal DFA instead of an NFA")
+	flags.StringVar(&expr, "expression", "", "provide the regular expression (in place of a regex file)")
+	flags.StringVar(&expr, "e", "", "provide the regular expression (in place of a regex file) (short-hand)")
+	flags.Parse(args)
+
+	// Ensures there is a regular expression, or else one non-flag argument.
+	given := false
+	flags.Visit(func(f *flag.Flag) {
+		given = given || f.Name == "expression" || f.Name == "e"
+	})
+	if given == (flags.NArg() == 1) || flags.NArg() > 1 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide either the regex file or the regular expression.")
+		os.Exit(1)
+	}
+
+	var m machine.Machine
+	var err error
+	switch {
+	case given && toDFA:
+		m, err = regex.CompileDFA(expr)
+	case given:
+		m, err = regex.Compile(expr)
+	case toDFA:
+		m, err = regex.MinimalDFA(buildMachine(flags.Arg(0), machine.REGEX))
+	default:
+		m = buildMachine(flags.Arg(0), machine.REGEX)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	err = yaml.Write(os.Stdout, describe(m))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// debugHelp lists the commands of the debugger.
+const debugHelp = `Commands:
+  step [N]                  (s)  take N steps, 1 by default
+  back [N]                  (b)  undo N steps, 1 by default
+  continue                  (c)  take steps until a breakpoint, or the machine halts or errors
+  break STATE [SYMBOL...]        stop when the machine is in STATE (reading SYMBOL, one for each tape)
+  delete [N]                     delete breakpoint N, or every breakpoint
+  breakpoints               (l)  list the breakpoints
+  print                     (p)  print the current configuration
+  restart                   (r)  go back to the start
+  help                      (h)  print this help
+  quit                      (q)  stop debugging
+An empty line repeats the last command.`
+
+// debugCommand simulates a machine on one test interactively,
+// stepping forwards and backwards and stopping at breakpoints.
+//
+//	tint debug [-m MACHINE_TYPE] [-max-steps N] MACHINE_FILE TEST
+func debugCommand(args []string) {
+	flags := flag.NewFlagSet("debug", flag.ExitOnError)
+	var machineType string
+	var maxSteps int
+	flags.StringVar(&machineType, "machine", "", "denote what type of machine is specified")
+	flags.StringVar(&machineType, "m", "", "denote what type of machine is specified (short-hand)")
+	flags.IntVar(&maxSteps, "max-steps", 100000, "the most steps continue takes before stopping (0 for no limit)")
+	flags.Parse(args)
+
+	// Ensures there are two non-flag arguments.
+	if flags.NArg() != 2 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the machine and a single, quoted test.")
+		os.Exit(1)
+	}
+
+	m := buildMachine(flags.Arg(0), machineType)
+	dbg := debugger{m: m, maxSteps: maxSteps, history: []machine.Configuration{m.Start(flags.Arg(1))}}
+	fmt.Println(`Debugging. Type "help" for the commands.`)
+	dbg.print()
+
+	in := bufio.NewScanner(os.Stdin)
+	last := ""
+	for {
+		fmt.Print("(tint) ")
+		if !in.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(in.Text())
+		if line == "" {
+			line = last
+		}
+		last = line
+		if !dbg.run(strings.Fields(line)) {
+			return
+		}
+	}
+}
+
+// breakpoint stops the debugger when the machine is in a state, reading the symbols if there are any.
+type breakpoint struct {
+	state   string
+	symbols []string
+}
+
+func (b breakpoint) String() string {
+	return strings.Join(append([]string{b.state}, b.symbols...), " ")
+}
+
+type debugger struct {
+	m           machine.Machine
+	maxSteps    int                     // the most steps continue takes, 0 for no limit
+	history     []machine.Configuration // every configuration from the start, the current one last
+	breakpoints []breakpoint
+}
+
+// run runs one command of the debugger.
+// Returns false if the debugger should quit.
+func (dbg *debugger) run(words []string) bool {
+	if len(words) == 0 {
+		return true
+	}
+	command, args := words[0], words[1:]
+
+	switch command {
+	case "step", "s":
+		n, ok := count(args)
+		if !ok {
+			return true
+		}
+		for i := 0; i < n; i++ {
+			if !dbg.step() {
+				break
+			}
+		}
+		dbg.print()
+	case "back", "b":
+		n, ok := count(args)
+		if !ok {
+			return true
+		}
+		if n >= len(dbg.history) {
+			n = len(dbg.history) - 1
+			fmt.Println("Back at the start.")
+		}
+		dbg.history = dbg.history[:len(dbg.history)-n]
+		dbg.print()
+	case "continue", "c":
+		dbg.cont()
+		dbg.print()
+	case "break":
+		if len(args) == 0 {
+			fmt.Println("Please provide the state to break in.")
+			return true
+		}
+		dbg.breakpoints = append(dbg.breakpoints, breakpoint{args[0], args[1:]})
+		fmt.Printf("Breakpoint %d: %s\n", len(dbg.breakpoints), dbg.breakpoints[len(dbg.breakpoints)-1])
+	case "delete":
+		if len(args) == 0 {
+			dbg.breakpoints = nil
+			fmt.Println("Deleted every breakpoint.")
+			return true
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(dbg.breakpoints) {
+			fmt.Printf("There is no breakpoint %s.\n", args[0])
+			return true
+		}
+		dbg.breakpoints = append(dbg.breakpoints[:n-1], dbg.breakpoints[n:]...)
+		fmt.Printf("Deleted breakpoint %d.\n", n)
+	case "breakpoints", "l":
+		if len(dbg.breakpoints) == 0 {
+			fmt.Println("There are no breakpoints.")
+		}
+		for i, b := range dbg.breakpoints {
+			fmt.Printf("Breakpoint %d: %s\n", i+1, b)
+		}
+	case "print", "p":
+		dbg.print()
+	case "restart", "r":
+		dbg.history = dbg.history[:1]
+		dbg.print()
+	case "help", "h":
+		fmt.Println(debugHelp)
+	case "quit", "q":
+		return false
+	default:
+		fmt.Printf("%s is not a command. Type \"help\" for the commands.\n", command)
+	}
+	return true
+}
+
+// count reads how many times to do a command, 1 if it is not given.
+func count(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 1, true
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		fmt.Printf("%s is not a number of steps.\n", args[0])
+		return 0, false
+	}
+	return n, true
+}
+
+// current is the configuration the machine is in.
+func (dbg *debugger) current() machine.Configuration {
+	return dbg.history[len(dbg.history)-1]
+}
+
+// halted is true if the machine accepted or rejected.
+func (dbg *debugger) halted() bool {
+	conf := dbg.current()
+	return dbg.m.IsAccept(conf) || dbg.m.IsReject(conf)
+}
+
+// step takes one step, and returns false if the machine could not.
+func (dbg *debugger) step() bool {
+	if dbg.halted() {
+		fmt.Println("The machine has halted, go back or restart to keep debugging.")
+		return false
+	}
+	conf, err := dbg.m.Step(dbg.current())
+	if err != nil {
+		fmt.Println("ERROR! Please see below:")
+		fmt.Println(err)
+		return false
+	}
+	dbg.history = append(dbg.history, conf)
+	return true
+}
+
+// cont takes steps until the machine reaches a breakpoint, halts, errors, or takes too many steps.
+func (dbg *debugger) cont() {
+	for steps := 0; dbg.maxSteps == 0 || steps < dbg.maxSteps; steps++ {
+		if !dbg.step() {
+			return
+		}
+		if dbg.halted() {
+			return
+		}
+		for i, b := range dbg.breakpoints {
+			if dbg.hits(b) {
+				fmt.Printf("Breakpoint %d: %s\n", i+1, b)
+				return
+			}
+		}
+	}
+	fmt.Printf("Did not reach a breakpoint after %d steps.\n", dbg.maxSteps)
+}
+
+// hits is true if the current configuration is at the breakpoint.
+func (dbg *debugger) hits(b breakpoint) bool {
+	conf := dbg.current()
+	if !conf.IsState(b.state) {
+		return false
+	}
+	if len(b.symbols) == 0 {
+		return true
+	}
+	for _, read := range reading(dbg.m, conf) {
+		if read[0] == b.state && len(read) > len(b.symbols) && same(read[1:1+len(b.symbols)], b.symbols) {
+			return true
+		}
+	}
+	return false
+}
+
+// reading returns what each branch of a configuration is about to read, as [state, symbol...],
+// with a symbol for each tape of a multi-tape Turing machine and the top of the stack after the symbol of a PDA.
+// Returns nothing if the machine cannot describe itself, or if the configuration cannot take a step.
+func reading(m machine.Machine, conf machine.Configuration) [][]string {
+	d, ok := m.(machine.Describer)
+	if !ok {
+		return nil
+	}
+	next, err := conf.GetNext()
+	if err != nil || len(next) == 0 {
+		return nil
+	}
+
+	var branches [][]string
+	switch d.Type() {
+	case machine.DFA, machine.ONE_WAY_TM, machine.TWO_WAY_TM, machine.MULTI_TAPE_TM:
+		// [state, symbol...]
+		branches = append(branches, next)
+	case machine.NFA:
+		// [symbol, state...]
+		for _, state := range next[1:] {
+			branches = append(branches, []string{state, next[0]})
+		}
+	case machine.NTM:
+		// [state, symbol, state, symbol, ...]
+		for i := 0; i+1 < len(next); i += 2 {
+			branches = append(branches, next[i:i+2])
+		}
+	case machine.PDA:
+		// [state, symbol, top, state, symbol, top, ...]
+		for i := 0; i+2 < len(next); i += 3 {
+			branches = append(branches, next[i:i+3])
+		}
+	}
+	return branches
+}
+
+func same(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// print prints the step and the current configuration, and whether the machine halted.
+func (dbg *debugger) print() {
+	conf := dbg.current()
+	fmt.Printf("Step %d:\n", len(dbg.history)-1)
+	fmt.Println(conf.Print())
+	if dbg.m.IsAccept(conf) {
+		fmt.Println("Accepted.")
+	} else if dbg.m.IsReject(conf) {
+		fmt.Println("Rejected.")
+	}
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+	"github.com/cjcodell1/tint/machine/pushdown"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/multi"
+	"github.com/cjcodell1/tint/machine/turing/ways/one"
+)
+
+// evenAs accepts an even number of a's.
+var evenAs, _ = dfa.MakeDFA([][]string{
+	{"even", "a", "odd"},
+	{"odd", "a", "even"},
+}, "even", []string{"even"})
+
+// endsInAB is in q0 and q1 after reading an a, so it reads b in two branches.
+var endsInAB, _ = nfa.MakeNFA([][]string{
+	{"q0", "a", "q0"},
+	{"q0", "b", "q0"},
+	{"q0", "a", "q1"},
+	{"q1", "b", "q2"},
+}, "q0", []string{"q2"})
+
+// anBn pushes an A for each a and pops one for each b.
+var anBn, _ = pushdown.MakePDA([][]string{
+	{"q0", "a", "", "q0", "A"},
+	{"q0", "b", "A", "q1", ""},
+	{"q1", "b", "A", "q1", ""},
+	{"q1", "", "$", "qa", ""},
+}, "q0", "$", []string{"qa"}, false)
+
+// copyTM copies the first tape to the second tape.
+var copyTM, _ = multi.MakeTuringMachine([][]string{
+	{"q0", "a", turing.Blank, "q0", "a", "a", turing.Right, turing.Right},
+	{"q0", turing.Blank, turing.Blank, "qa", turing.Blank, turing.Blank, turing.Stay, turing.Stay},
+}, 2, "q0", "qa", "qr")
+
+// forever moves right and never halts.
+var forever, _ = one.MakeTuringMachine([][]string{
+	{"q0", machine.Wildcard, "q0", machine.Wildcard, turing.Right},
+}, "q0", "qa", "qr")
+
+// hitT
+type hitT struct {
+	m     machine.Machine
+	input string
+	steps int      // steps taken before checking the breakpoint
+	words []string // the breakpoint, as it is typed after break
+	hit   bool
+}
+
+var hitTests []hitT
+
+// TestHits checks a breakpoint is hit in its state, reading its symbols if it has any.
+func TestHits(t *testing.T) {
+	for _, tc := range hitTests {
+		dbg := debugger{m: tc.m, history: []machine.Configuration{tc.m.Start(tc.input)}}
+		for i := 0; i < tc.steps; i++ {
+			dbg.step()
+		}
+		b := breakpoint{tc.words[0], tc.words[1:]}
+		if got := dbg.hits(b); got != tc.hit {
+			t.Errorf("breakpoint %s on %s after %d steps of %q == %t != %t",
+				b, dbg.current().Print(), tc.steps, tc.input, got, tc.hit)
+		}
+	}
+}
+
+func init() {
+	hitTests = []hitT{
+		// DFA: [state, symbol]
+		{evenAs, "a a", 0, []string{"even"}, true},
+		{evenAs, "a a", 0, []string{"even", "a"}, true},
+		{evenAs, "a a", 0, []string{"even", "b"}, false},
+		{evenAs, "a a", 0, []string{"odd"}, false},
+		{evenAs, "a a", 1, []string{"odd", "a"}, true},
+
+		// NFA: every branch reading the symbol
+		{endsInAB, "a b", 1, []string{"q1"}, true},
+		{endsInAB, "a b", 1, []string{"q1", "b"}, true},
+		{endsInAB, "a b", 1, []string{"q0", "b"}, true},
+		{endsInAB, "a b", 1, []string{"q1", "a"}, false},
+		{endsInAB, "a b", 1, []string{"q2"}, false},
+
+		// PDA: [state, symbol, top]
+		{anBn, "a b", 0, []string{"q0", "a"}, true},
+		{anBn, "a b", 0, []string{"q0", "a", "$"}, true},
+		{anBn, "a b", 1, []string{"q0", "b", "A"}, true},
+		{anBn, "a b", 1, []string{"q0", "b", "$"}, false},
+		{anBn, "a b", 1, []string{"q0", "a"}, false},
+		{anBn, "a b", 1, []string{"q0", "b", "A", "A"}, false},
+
+		// multi-tape: [state, symbol on each tape]
+		{copyTM, "a a", 1, []string{"q0"}, true},
+		{copyTM, "a a", 1, []string{"q0", "a"}, true},
+		{copyTM, "a a", 1, []string{"q0", "a", turing.Blank}, true},
+		{copyTM, "a a", 1, []string{"q0", "a", "a"}, false},
+		{copyTM, "a a", 2, []string{"q0", turing.Blank, turing.Blank}, true},
+		{copyTM, "a a", 2, []string{"qa"}, false},
+	}
+}
+
+// debugT
+type debugT struct {
+	m           machine.Machine
+	input       string
+	maxSteps    int
+	commands    []string
+	steps       int      // the step the debugger is on after the commands
+	breakpoints []string // the breakpoints after the commands, in order
+}
+
+var debugTests []debugT
+
+// TestDebuggerRun runs each command of the debugger in turn, and checks the step it ends on and its breakpoints.
+func TestDebuggerRun(t *testing.T) {
+	for _, tc := range debugTests {
+		dbg := debugger{m: tc.m, maxSteps: tc.maxSteps, history: []machine.Configuration{tc.m.Start(tc.input)}}
+		for _, command := range tc.commands {
+			if !dbg.run(strings.Fields(command)) {
+				break
+			}
+		}
+
+		if steps := len(dbg.history) - 1; steps != tc.steps {
+			t.Errorf("%q on %q ended on step %d, not %d", tc.commands, tc.input, steps, tc.steps)
+		}
+		breakpoints := []string{}
+		for _, b := range dbg.breakpoints {
+			breakpoints = append(breakpoints, b.String())
+		}
+		if fmt.Sprint(breakpoints) != fmt.Sprint(tc.breakpoints) {
+			t.Errorf("%q on %q ended with breakpoints %q, not %q", tc.commands, tc.input, breakpoints, tc.breakpoints)
+		}
+	}
+}
+
+func init() {
+	debugTests = []debugT{
+		// step and back
+		{evenAs, "a a a", 0, []string{"step 2"}, 2, []string{}},
+		{evenAs, "a a a", 0, []string{"step 2", "back"}, 1, []string{}},
+		{evenAs, "a a a", 0, []string{"step 2", "back 2"}, 0, []string{}},
+		{evenAs, "a a a", 0, []string{"step 2", "back 5"}, 0, []string{}},
+		{evenAs, "a a a", 0, []string{"back"}, 0, []string{}},
+		{evenAs, "a a a", 0, []string{"step 5"}, 3, []string{}}, // halts after 3 steps
+		{evenAs, "a a a", 0, []string{"step 0", "back x"}, 0, []string{}},
+		{evenAs, "a a a", 0, []string{"step 3", "restart"}, 0, []string{}},
+
+		// break and delete, renumbering the breakpoints after the one deleted
+		{evenAs, "a", 0, []string{"break even", "break odd a", "break even b"}, 0, []string{"even", "odd a", "even b"}},
+		{evenAs, "a", 0, []string{"break even", "break odd a", "break even b", "delete 2"}, 0, []string{"even", "even b"}},
+		{evenAs, "a", 0, []string{"break even", "break odd a", "break even b", "delete 2", "delete 2"}, 0, []string{"even"}},
+		{evenAs, "a", 0, []string{"break even", "break odd a", "break even b", "delete 1", "delete 1"}, 0, []string{"even b"}},
+		{evenAs, "a", 0, []string{"break even", "break odd a", "delete 3", "delete 0", "delete x"}, 0, []string{"even", "odd a"}},
+		{evenAs, "a", 0, []string{"break even", "break odd a", "delete"}, 0, []string{}},
+		{evenAs, "a", 0, []string{"break"}, 0, []string{}},
+
+		// continue stops at a breakpoint, when the machine halts, or after maxSteps
+		{evenAs, "a a a a", 0, []string{"break odd", "continue"}, 1, []string{"odd"}},
+		{evenAs, "a a a a", 0, []string{"break odd", "continue", "continue"}, 3, []string{"odd"}},
+		{evenAs, "a a a a", 0, []string{"continue"}, 4, []string{}},
+		{forever, "a", 10, []string{"continue"}, 10, []string{}},
+		{forever, "a", 10, []string{"continue", "continue"}, 20, []string{}},
+		{forever, "a", 10, []string{"break q1", "continue", "back 5"}, 5, []string{"q1"}},
+		{anBn, "a a b b", 100, []string{"break q1 b A", "continue"}, 3, []string{"q1 b A"}},
+		{copyTM, "a a", 100, []string{"break q0 _ _", "continue"}, 2, []string{"q0 _ _"}},
+
+		// quit stops running commands
+		{evenAs, "a a", 0, []string{"quit", "step"}, 0, []string{}},
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cjcodell1/tint/builder/yaml"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+)
+
+// determinizeCommand writes a DFA equivalent to an NFA to stdout as YAML,
+// after comments that report which set of NFA states each DFA state is.
+//
+//	tint determinize [-subsets] MACHINE_FILE
+func determinizeCommand(args []string) {
+	flags := flag.NewFlagSet("determinize", flag.ExitOnError)
+	var subsets bool
+	flags.BoolVar(&subsets, "subsets", false, "name each state after the set of NFA states it is, like {q0,q2}")
+	flags.BoolVar(&subsets, "s", false, "name each state after the set of NFA states it is, like {q0,q2} (short-hand)")
+	flags.Parse(args)
+
+	// Ensures there is one non-flag argument.
+	if flags.NArg() != 1 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the NFA to determinize.")
+		os.Exit(1)
+	}
+
+	m, sets, err := nfa.Determinize(buildMachine(flags.Arg(0), machine.NFA), subsets)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	d := describe(m)
+
+	// the report of which set each state is
+	fmt.Printf("# the DFA of %s, with %d states\n", flags.Arg(0), len(d.States()))
+	if !subsets {
+		for _, state := range d.States() {
+			fmt.Printf("# %s: {%s}\n", state, strings.Join(sets[state], ", "))
+		}
+	}
+
+	err = yaml.Write(os.Stdout, d)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+// equivalentCommand checks if two DFAs recognize the same language.
+// If they do not, it prints the shortest input they disagree on and exits with 1.
+//
+//	tint equivalent MACHINE_FILE MACHINE_FILE
+func equivalentCommand(args []string) {
+	flags := flag.NewFlagSet("equivalent", flag.ExitOnError)
+	flags.Parse(args)
+
+	// Ensures there are two non-flag arguments.
+	if flags.NArg() != 2 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the two DFAs to compare.")
+		os.Exit(1)
+	}
+
+	first := buildMachine(flags.Arg(0), machine.DFA)
+	second := buildMachine(flags.Arg(1), machine.DFA)
+	counter, err := dfa.Equivalent(first, second)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if counter == nil {
+		fmt.Println("Equivalent.")
+		return
+	}
+
+	accepts, rejects := flags.Arg(0), flags.Arg(1)
+	if !counter.FirstAccepts {
+		accepts, rejects = rejects, accepts
+	}
+	fmt.Println("Not equivalent.")
+	fmt.Printf("%q is accepted by %s and rejected by %s.\n", strings.Join(counter.Input, " "), accepts, rejects)
+	os.Exit(1)
+}
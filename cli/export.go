@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cjcodell1/tint/builder/jflap"
+	"github.com/cjcodell1/tint/export"
+)
+
+// exportCommand writes a machine to stdout in another format.
+//
+//	tint export -format dot|jff -m MACHINE_TYPE MACHINE_FILE
+func exportCommand(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	var machineType string
+	var format string
+	flags.StringVar(&machineType, "machine", "", "denote what type of machine is specified")
+	flags.StringVar(&machineType, "m", "", "denote what type of machine is specified (short-hand)")
+	flags.StringVar(&format, "format", "dot", "the format to export to: dot or jff")
+	flags.StringVar(&format, "f", "dot", "the format to export to: dot or jff (short-hand)")
+	flags.Parse(args)
+
+	// Ensures there is one non-flag argument.
+	if flags.NArg() != 1 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the machine to export.")
+		os.Exit(1)
+	}
+
+	d := describe(buildMachine(flags.Arg(0), machineType))
+
+	var err error
+	switch format {
+	case "dot":
+		err = export.DOT(os.Stdout, d)
+	case "jff":
+		err = jflap.Write(os.Stdout, d)
+	default:
+		err = fmt.Errorf("%s is not a format that can be exported to.", format)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cjcodell1/tint/file"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/sim"
+)
+
+// junitSuite is a <testsuite> of JUnit XML, with every test of one machine.
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+// junitCase is a <testcase>, with a <failure> or <error> if it did not pass.
+type junitCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitProblem    `xml:"failure,omitempty"`
+	Error      *junitProblem    `xml:"error,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReporter writes the results as a JUnit XML <testsuite> named by the machine file,
+// with a <testcase> named by the input of each test.
+// A test that errors is an <error>, and a test without the outcome it expects is a <failure>.
+type junitReporter struct {
+	w     io.Writer
+	suite junitSuite
+	total time.Duration
+}
+
+func newJUnitReporter(w io.Writer, path string) reporter {
+	return &junitReporter{w: w, suite: junitSuite{Name: path}}
+}
+
+// seconds writes a duration in seconds, the unit of JUnit XML.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.6f", d.Seconds())
+}
+
+// write writes the suite.
+func (r *junitReporter) write() {
+	r.suite.Tests = len(r.suite.Cases)
+	r.suite.Time = seconds(r.total)
+	// a struct of strings and ints can always be marshalled
+	b, _ := xml.MarshalIndent(r.suite, "", "  ")
+	fmt.Fprintln(r.w, xml.Header+string(b))
+}
+
+// fatal writes a suite with only the error, so it is reported like a test that errored.
+func (r *junitReporter) fatal(msg string, err error) {
+	r.suite.Errors = 1
+	r.suite.Cases = []junitCase{{
+		Name:      msg,
+		ClassName: r.suite.Name,
+		Time:      seconds(0),
+		Error:     &junitProblem{err.Error(), sim.Error, err.Error()},
+	}}
+	r.write()
+}
+
+func (r *junitReporter) begin(test file.Test) {}
+
+func (r *junitReporter) step(n int, conf machine.Configuration) {}
+
+func (r *junitReporter) result(res result) {
+	c := junitCase{
+		Name:      res.test.Input,
+		ClassName: r.suite.Name,
+		Time:      seconds(res.Elapsed),
+		Properties: &junitProperties{[]junitProperty{
+			{"steps", fmt.Sprint(res.Steps)},
+			{"outcome", res.Outcome},
+		}},
+	}
+	if res.test.Input == "" {
+		c.Name = "(empty string)"
+	}
+	if res.test.Expect != "" {
+		c.Properties.Properties = append(c.Properties.Properties, junitProperty{"expect", res.test.Expect})
+	}
+	r.total += res.Elapsed
+
+	switch {
+	case res.Outcome == sim.Error:
+		r.suite.Errors++
+		c.Error = &junitProblem{res.Err.Error(), sim.Error, res.Final.Print()}
+	case res.test.Expect != "" && !res.passed():
+		r.suite.Failures++
+		msg := fmt.Sprintf("Expected to %s, but the outcome was %s.", res.test.Expect, res.Outcome)
+		c.Failure = &junitProblem{msg, res.Outcome, res.Final.Print()}
+	}
+	r.suite.Cases = append(r.suite.Cases, c)
+}
+
+func (r *junitReporter) end(total summary) {
+	r.write()
+}
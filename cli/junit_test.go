@@ -0,0 +1,53 @@
+package cli
+
+func init() {
+	reportTests = append(reportTests, []report{
+		{junitFormat, "nothing", []result{}, nil,
+			`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="even.yaml" tests="0" failures="0" errors="0" time="0.000000"></testsuite>
+`},
+		// a test that errors is an error even when it expects an outcome, and the empty string is named
+		{junitFormat, "every outcome", every, nil,
+			`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="even.yaml" tests="4" failures="1" errors="1" time="6.000000">
+  <testcase name="a a" classname="even.yaml" time="1.500000">
+    <properties>
+      <property name="steps" value="2"></property>
+      <property name="outcome" value="accept"></property>
+      <property name="expect" value="accept"></property>
+    </properties>
+  </testcase>
+  <testcase name="a" classname="even.yaml" time="1.500000">
+    <properties>
+      <property name="steps" value="1"></property>
+      <property name="outcome" value="reject"></property>
+      <property name="expect" value="accept"></property>
+    </properties>
+    <failure message="Expected to accept, but the outcome was reject." type="reject">odd: </failure>
+  </testcase>
+  <testcase name="a b" classname="even.yaml" time="1.500000">
+    <properties>
+      <property name="steps" value="1"></property>
+      <property name="outcome" value="error"></property>
+      <property name="expect" value="reject"></property>
+    </properties>
+    <error message="No transition found for state: &#34;odd&#34; and symbol &#34;b&#34;" type="error">odd: b</error>
+  </testcase>
+  <testcase name="(empty string)" classname="even.yaml" time="1.500000">
+    <properties>
+      <property name="steps" value="0"></property>
+      <property name="outcome" value="accept"></property>
+    </properties>
+  </testcase>
+</testsuite>
+`},
+		{junitFormat, "a fatal error", nil, broken,
+			`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="even.yaml" tests="1" failures="0" errors="1" time="0.000000">
+  <testcase name="There was an error building your machine." classname="even.yaml" time="0.000000">
+    <error message="even.yaml:1:1: The file is empty." type="error">even.yaml:1:1: The file is empty.</error>
+  </testcase>
+</testsuite>
+`},
+	}...)
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cjcodell1/tint/builder/yaml"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+// minimizeCommand writes the minimal DFA equivalent to a DFA to stdout as YAML,
+// after comments that report which states were merged.
+//
+//	tint minimize MACHINE_FILE
+func minimizeCommand(args []string) {
+	flags := flag.NewFlagSet("minimize", flag.ExitOnError)
+	flags.Parse(args)
+
+	// Ensures there is one non-flag argument.
+	if flags.NArg() != 1 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the DFA to minimize.")
+		os.Exit(1)
+	}
+
+	original := describe(buildMachine(flags.Arg(0), machine.DFA))
+	m, merged, err := dfa.Minimize(original)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	min := describe(m)
+
+	// the report of which states were merged into which
+	fmt.Printf("# the minimal DFA of %s, with %d states instead of %d\n", flags.Arg(0), len(min.States()), len(original.States()))
+	kept := make(map[string]bool)
+	for _, state := range min.States() {
+		if len(merged[state]) == 0 {
+			fmt.Printf("# %s: added to complete the transitions\n", state)
+		} else {
+			fmt.Printf("# %s: %s\n", state, strings.Join(merged[state], ", "))
+		}
+		for _, s := range merged[state] {
+			kept[s] = true
+		}
+	}
+	unreachable := []string{}
+	for _, state := range original.States() {
+		if !kept[state] {
+			unreachable = append(unreachable, state)
+		}
+	}
+	if len(unreachable) > 0 {
+		fmt.Printf("# unreachable: %s\n", strings.Join(unreachable, ", "))
+	}
+
+	err = yaml.Write(os.Stdout, min)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
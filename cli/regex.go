@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cjcodell1/tint/machine/finite/regex"
+)
+
+// regexCommand writes a regular expression for the language of a DFA or NFA to stdout,
+// either by itself or as a regex file.
+//
+//	tint regex [-m dfa|nfa] [-yaml] MACHINE_FILE
+func regexCommand(args []string) {
+	flags := flag.NewFlagSet("regex", flag.ExitOnError)
+	var machineType string
+	var asYAML bool
+	flags.StringVar(&machineType, "machine", "", "denote what type of machine is specified: dfa or nfa")
+	flags.StringVar(&machineType, "m", "", "denote what type of machine is specified: dfa or nfa (short-hand)")
+	flags.BoolVar(&asYAML, "yaml", false, "write the regular expression as a regex file")
+	flags.Parse(args)
+
+	// Ensures there is one non-flag argument.
+	if flags.NArg() != 1 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the machine to build a regular expression for.")
+		os.Exit(1)
+	}
+
+	expr, err := regex.FromAutomaton(describe(buildMachine(flags.Arg(0), machineType)))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if asYAML {
+		fmt.Printf("---\n# the regular expression of %s\n\nregex: %s\n", flags.Arg(0), strconv.Quote(expr))
+	} else {
+		fmt.Println(expr)
+	}
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cjcodell1/tint/file"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/sim"
+)
+
+// The formats the results of simulating tests can be reported in.
+const (
+	textFormat  = "text"
+	jsonFormat  = "json"
+	jsonlFormat = "jsonl"
+	junitFormat = "junit"
+)
+
+// reporters make a reporter for each format, by name,
+// that writes to w about the machine in the file at path.
+var reporters = map[string]func(w io.Writer, path string) reporter{
+	textFormat:  newTextReporter,
+	jsonFormat:  newJSONReporter,
+	jsonlFormat: newJSONLReporter,
+	junitFormat: newJUnitReporter,
+}
+
+// summary counts the outcomes of every test.
+type summary struct {
+	Accepted   int `json:"accepted"`
+	Rejected   int `json:"rejected"`
+	Errors     int `json:"errors"`
+	DidNotHalt int `json:"did_not_halt"`
+	Passed     int `json:"passed"`
+	Failed     int `json:"failed"`
+}
+
+// reporter writes the results of simulating a machine on the tests.
+type reporter interface {
+	// Reports an error that stops the tests from being simulated.
+	fatal(msg string, err error)
+	// Called before a test is simulated.
+	begin(test file.Test)
+	// Called with each configuration as the test is simulated, only with -v.
+	step(n int, conf machine.Configuration)
+	// Called after a test is simulated.
+	result(res result)
+	// Called after every test is simulated.
+	end(total summary)
+}
+
+// textReporter writes the results for people to read.
+type textReporter struct {
+	w        io.Writer
+	failures []string // the expected and actual outcomes of each failed test, diff style
+}
+
+func newTextReporter(w io.Writer, path string) reporter {
+	return &textReporter{w: w}
+}
+
+func (r *textReporter) fatal(msg string, err error) {
+	fmt.Fprintln(r.w, msg)
+	fmt.Fprintln(r.w, err)
+}
+
+func (r *textReporter) begin(test file.Test) {
+	fmt.Fprintf(r.w, "Simulating with \"%s\".\n", test.Input)
+}
+
+func (r *textReporter) step(n int, conf machine.Configuration) {
+	fmt.Fprintln(r.w, conf.Print())
+}
+
+func (r *textReporter) result(res result) {
+	switch res.Outcome {
+	case sim.Accept:
+		if res.path != nil {
+			fmt.Fprintln(r.w, "Accepting path:")
+			for _, step := range res.path {
+				fmt.Fprintln(r.w, step.Print())
+			}
+		}
+		fmt.Fprintln(r.w, "Accepted.")
+	case sim.Reject:
+		fmt.Fprintln(r.w, "Rejected.")
+	case sim.Loop:
+		fmt.Fprintf(r.w, "Loops forever! The configuration after step %d repeats after step %d.\n", res.LoopFrom, res.Steps)
+	case sim.Timeout:
+		if stepsFlag > 0 && res.Steps >= stepsFlag {
+			fmt.Fprintf(r.w, "Did not halt after %d steps.\n", res.Steps)
+		} else {
+			fmt.Fprintf(r.w, "Did not halt after %s (%d steps).\n", timeoutFlag, res.Steps)
+		}
+	case sim.Error:
+		fmt.Fprintln(r.w, "ERROR! Please see below:")
+		fmt.Fprintln(r.w, res.Err)
+		fmt.Fprintln(r.w, "Skipping this test.")
+	}
+
+	// check the outcome if the test expects one
+	if res.test.Expect != "" {
+		if res.passed() {
+			fmt.Fprintln(r.w, "Passed.")
+		} else {
+			fmt.Fprintf(r.w, "FAILED! Expected to %s.\n", res.test.Expect)
+			r.failures = append(r.failures,
+				fmt.Sprintf("- %s: %s", res.test.Expect, res.test.Input),
+				fmt.Sprintf("+ %s: %s", res.Outcome, res.test.Input))
+		}
+	}
+	fmt.Fprintln(r.w)
+}
+
+func (r *textReporter) end(total summary) {
+	fmt.Fprintf(r.w, "%d accepted.\n", total.Accepted)
+	fmt.Fprintf(r.w, "%d rejected.\n", total.Rejected)
+	fmt.Fprintf(r.w, "%d errors.\n", total.Errors)
+	fmt.Fprintf(r.w, "%d did not halt.\n", total.DidNotHalt)
+
+	// Report the tests that did not have the expected outcome
+	if total.Passed != 0 || total.Failed != 0 {
+		fmt.Fprintf(r.w, "%d passed.\n", total.Passed)
+		fmt.Fprintf(r.w, "%d failed.\n", total.Failed)
+	}
+	if len(r.failures) != 0 {
+		fmt.Fprintln(r.w)
+		fmt.Fprintln(r.w, "--- expected")
+		fmt.Fprintln(r.w, "+++ actual")
+		for _, failure := range r.failures {
+			fmt.Fprintln(r.w, failure)
+		}
+	}
+}
+
+// configurationJSON is a configuration as JSON.
+// The state and tapes are only known for machines with tapes, but every configuration has its text.
+type configurationJSON struct {
+	State string     `json:"state,omitempty"`
+	Tapes [][]string `json:"tapes,omitempty"`
+	Heads []int      `json:"heads,omitempty"`
+	Text  string     `json:"text"` // the configuration as -v prints it
+}
+
+func toJSON(conf machine.Configuration) *configurationJSON {
+	if conf == nil {
+		return nil
+	}
+	c := configurationJSON{Text: conf.Print()}
+	if tc, ok := conf.(machine.TapeConfiguration); ok {
+		c.State = tc.State()
+		c.Tapes, c.Heads = tc.Tapes()
+	}
+	return &c
+}
+
+// resultJSON is the result of a test as JSON.
+type resultJSON struct {
+	Input   string               `json:"input"`
+	Expect  string               `json:"expect,omitempty"`
+	Passed  *bool                `json:"passed,omitempty"` // only when the test expects an outcome
+	Outcome string               `json:"outcome"`
+	Steps   int                  `json:"steps"`
+	Final   *configurationJSON   `json:"final"`
+	Error   string               `json:"error,omitempty"`
+	Trace   []*configurationJSON `json:"trace,omitempty"` // only with -v
+	Path    []*configurationJSON `json:"path,omitempty"`  // only with -p
+}
+
+// jsonReporter writes one JSON object with the results of every test and the summary.
+// With -v, each result has the trace of every configuration.
+type jsonReporter struct {
+	w       io.Writer
+	trace   []*configurationJSON // the trace of the test being simulated
+	results []resultJSON
+	lines   bool // writes each result as its own line as it finishes, and the summary last
+}
+
+func newJSONReporter(w io.Writer, path string) reporter {
+	return &jsonReporter{w: w, results: []resultJSON{}}
+}
+
+func newJSONLReporter(w io.Writer, path string) reporter {
+	return &jsonReporter{w: w, lines: true}
+}
+
+// write writes a JSON object on its own line.
+func (r *jsonReporter) write(v interface{}) {
+	// a struct of strings, ints, and slices of them can always be marshalled
+	b, _ := json.Marshal(v)
+	fmt.Fprintln(r.w, string(b))
+}
+
+func (r *jsonReporter) fatal(msg string, err error) {
+	r.write(struct {
+		Error string `json:"error"`
+	}{msg + " " + err.Error()})
+}
+
+func (r *jsonReporter) begin(test file.Test) {
+	r.trace = nil
+}
+
+func (r *jsonReporter) step(n int, conf machine.Configuration) {
+	r.trace = append(r.trace, toJSON(conf))
+}
+
+func (r *jsonReporter) result(res result) {
+	j := resultJSON{
+		Input:   res.test.Input,
+		Expect:  res.test.Expect,
+		Outcome: res.Outcome,
+		Steps:   res.Steps,
+		Final:   toJSON(res.Final),
+		Trace:   r.trace,
+	}
+	if res.test.Expect != "" {
+		passed := res.passed()
+		j.Passed = &passed
+	}
+	if res.Err != nil {
+		j.Error = res.Err.Error()
+	}
+	for _, conf := range res.path {
+		j.Path = append(j.Path, toJSON(conf))
+	}
+
+	if r.lines {
+		r.write(j)
+	} else {
+		r.results = append(r.results, j)
+	}
+}
+
+func (r *jsonReporter) end(total summary) {
+	if r.lines {
+		r.write(struct {
+			Summary summary `json:"summary"`
+		}{total})
+		return
+	}
+	r.write(struct {
+		Tests   []resultJSON `json:"tests"`
+		Summary summary      `json:"summary"`
+	}{r.results, total})
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cjcodell1/tint/file"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/sim"
+)
+
+// report
+type report struct {
+	format  string
+	name    string
+	results []result // reported in order, then their summary
+	fatal   error    // reported instead of the results if it is not nil
+	expect  string
+}
+
+var reportTests []report
+
+// every has a result of each kind: passed, failed, errored, and for the empty string without an expected outcome.
+var every = []result{
+	test("a a", file.Accept),
+	test("a", file.Accept),
+	test("a b", file.Reject),
+	test("", ""),
+}
+
+// broken is the error of a machine that cannot be built.
+var broken = errors.New("even.yaml:1:1: The file is empty.")
+
+// test simulates a DFA that accepts an even number of a's on the input, as if it took 1.5 seconds.
+func test(input string, expect string) result {
+	evenAs, _ := dfa.MakeDFA([][]string{
+		{"even", "a", "odd"},
+		{"odd", "a", "even"},
+	}, "even", []string{"even"})
+	res := result{test: file.Test{Input: input, Expect: expect}}
+	res.Result = sim.Run(context.Background(), evenAs, input, sim.Options{})
+	res.Elapsed = 1500 * time.Millisecond
+	return res
+}
+
+// total sums the results the same way Run does.
+func total(results []result) summary {
+	var s summary
+	for _, res := range results {
+		switch res.Outcome {
+		case sim.Accept:
+			s.Accepted++
+		case sim.Reject:
+			s.Rejected++
+		case sim.Error:
+			s.Errors++
+		default:
+			s.DidNotHalt++
+		}
+		if res.test.Expect != "" {
+			if res.passed() {
+				s.Passed++
+			} else {
+				s.Failed++
+			}
+		}
+	}
+	return s
+}
+
+func TestReporters(t *testing.T) {
+	for _, tc := range reportTests {
+		var w bytes.Buffer
+		r := reporters[tc.format](&w, "even.yaml")
+		if tc.fatal != nil {
+			r.fatal("There was an error building your machine.", tc.fatal)
+		} else {
+			for _, res := range tc.results {
+				r.begin(res.test)
+				r.result(res)
+			}
+			r.end(total(tc.results))
+		}
+		if got := w.String(); got != tc.expect {
+			t.Errorf("%s reporter on %s wrote\n%s\n!=\n%s", tc.format, tc.name, got, tc.expect)
+		}
+	}
+}
+
+func init() {
+	reportTests = append(reportTests, []report{
+		{jsonFormat, "nothing", []result{}, nil,
+			`{"tests":[],"summary":{"accepted":0,"rejected":0,"errors":0,"did_not_halt":0,"passed":0,"failed":0}}
+`},
+		{jsonFormat, "every outcome", every, nil,
+			`{"tests":[{"input":"a a","expect":"accept","passed":true,"outcome":"accept","steps":2,"final":{"state":"even","tapes":[[]],"heads":[0],"text":"even: "}},{"input":"a","expect":"accept","passed":false,"outcome":"reject","steps":1,"final":{"state":"odd","tapes":[[]],"heads":[0],"text":"odd: "}},{"input":"a b","expect":"reject","passed":false,"outcome":"error","steps":1,"final":{"state":"odd","tapes":[["b"]],"heads":[0],"text":"odd: b"},"error":"No transition found for state: \"odd\" and symbol \"b\""},{"input":"","outcome":"accept","steps":0,"final":{"state":"even","tapes":[[]],"heads":[0],"text":"even: "}}],"summary":{"accepted":2,"rejected":1,"errors":1,"did_not_halt":0,"passed":1,"failed":2}}
+`},
+		{jsonFormat, "a fatal error", nil, broken,
+			`{"error":"There was an error building your machine. even.yaml:1:1: The file is empty."}
+`},
+		{jsonlFormat, "nothing", []result{}, nil,
+			`{"summary":{"accepted":0,"rejected":0,"errors":0,"did_not_halt":0,"passed":0,"failed":0}}
+`},
+		{jsonlFormat, "every outcome", every, nil,
+			`{"input":"a a","expect":"accept","passed":true,"outcome":"accept","steps":2,"final":{"state":"even","tapes":[[]],"heads":[0],"text":"even: "}}
+{"input":"a","expect":"accept","passed":false,"outcome":"reject","steps":1,"final":{"state":"odd","tapes":[[]],"heads":[0],"text":"odd: "}}
+{"input":"a b","expect":"reject","passed":false,"outcome":"error","steps":1,"final":{"state":"odd","tapes":[["b"]],"heads":[0],"text":"odd: b"},"error":"No transition found for state: \"odd\" and symbol \"b\""}
+{"input":"","outcome":"accept","steps":0,"final":{"state":"even","tapes":[[]],"heads":[0],"text":"even: "}}
+{"summary":{"accepted":2,"rejected":1,"errors":1,"did_not_halt":0,"passed":1,"failed":2}}
+`},
+		{jsonlFormat, "a fatal error", nil, broken,
+			`{"error":"There was an error building your machine. even.yaml:1:1: The file is empty."}
+`},
+	}...)
+}
@@ -0,0 +1,390 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// The escape codes the TUI draws with.
+const (
+	clearScreen   = "\x1b[H\x1b[2J"
+	altScreen     = "\x1b[?1049h\x1b[?25l" // switch to the alternate screen and hide the cursor
+	mainScreen    = "\x1b[?25h\x1b[?1049l" // show the cursor and switch back to the main screen
+	reverseVideo  = "\x1b[7m"
+	bold          = "\x1b[1m"
+	resetGraphics = "\x1b[0m"
+)
+
+// The fastest and slowest the TUI plays a simulation.
+const (
+	minDelay = time.Millisecond
+	maxDelay = 2 * time.Second
+)
+
+// tuiControls are the keys the TUI reads.
+const tuiControls = "space play/pause   → l step   ← h back   + faster   - slower   r restart   q quit"
+
+// tuiCommand animates a simulation of a machine on one test in the terminal,
+// drawing the tape(s), state, and transition that was just followed.
+//
+//	tint tui [-m MACHINE_TYPE] [-delay DURATION] MACHINE_FILE TEST
+func tuiCommand(args []string) {
+	flags := flag.NewFlagSet("tui", flag.ExitOnError)
+	var machineType string
+	var delay time.Duration
+	flags.StringVar(&machineType, "machine", "", "denote what type of machine is specified")
+	flags.StringVar(&machineType, "m", "", "denote what type of machine is specified (short-hand)")
+	flags.DurationVar(&delay, "delay", 200*time.Millisecond, "the time between steps while playing, e.g. 50ms")
+	flags.Parse(args)
+
+	// Ensures there are two non-flag arguments.
+	if flags.NArg() != 2 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the machine and a single, quoted test.")
+		os.Exit(1)
+	}
+	if delay < minDelay {
+		delay = minDelay
+	}
+
+	m := buildMachine(flags.Arg(0), machineType)
+
+	// Reads keys one at a time, instead of a line at a time.
+	old, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		fmt.Println("The TUI must be run in a terminal.")
+		os.Exit(1)
+	}
+	fmt.Print(altScreen)
+	defer func() {
+		fmt.Print(mainScreen)
+		term.Restore(int(os.Stdin.Fd()), old)
+	}()
+
+	v := viewer{m: m, name: flags.Arg(0), input: flags.Arg(1), delay: delay}
+	v.restart()
+	v.play(readKeys())
+}
+
+// The keys the TUI reads, other than single characters.
+const (
+	keyRight = "right"
+	keyLeft  = "left"
+)
+
+// readKeys reads keys from stdin until it closes.
+func readKeys() <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 16)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			in := string(buf[:n])
+			switch {
+			case in == "\x1b[C":
+				keys <- keyRight
+			case in == "\x1b[D":
+				keys <- keyLeft
+			case strings.HasPrefix(in, "\x1b"):
+				// ignores the other escape sequences
+			default:
+				for _, r := range in {
+					keys <- string(r)
+				}
+			}
+		}
+	}()
+	return keys
+}
+
+// frame is a configuration in the history of a simulation.
+type frame struct {
+	conf  machine.Configuration
+	fired []string // the transition followed to get to the configuration, or nil if it is not known
+}
+
+type viewer struct {
+	m       machine.Machine
+	name    string // the path of the machine file
+	input   string
+	delay   time.Duration // the time between steps while playing
+	history []frame       // every configuration from the start, the current one last
+	playing bool
+	err     error // the error of the last step, if it errored
+}
+
+// play draws the simulation and takes steps until the user quits.
+func (v *viewer) play(keys <-chan string) {
+	ticker := time.NewTicker(v.delay)
+	defer ticker.Stop()
+
+	v.draw()
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return
+			}
+			switch key {
+			case "q", "\x03": // \x03 is ctrl-c
+				return
+			case " ":
+				v.playing = !v.playing && !v.halted()
+			case keyRight, "l":
+				v.playing = false
+				v.step()
+			case keyLeft, "h":
+				v.playing = false
+				v.back()
+			case "+", "=":
+				v.delay = clamp(v.delay / 2)
+				ticker.Reset(v.delay)
+			case "-", "_":
+				v.delay = clamp(v.delay * 2)
+				ticker.Reset(v.delay)
+			case "r":
+				v.playing = false
+				v.restart()
+			default:
+				continue
+			}
+			v.draw()
+		case <-ticker.C:
+			if !v.playing {
+				continue
+			}
+			if !v.step() {
+				v.playing = false
+			}
+			v.draw()
+		}
+	}
+}
+
+func clamp(delay time.Duration) time.Duration {
+	if delay < minDelay {
+		return minDelay
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+func (v *viewer) restart() {
+	v.history = []frame{{v.m.Start(v.input), nil}}
+	v.err = nil
+}
+
+func (v *viewer) current() machine.Configuration {
+	return v.history[len(v.history)-1].conf
+}
+
+func (v *viewer) halted() bool {
+	return v.m.IsAccept(v.current()) || v.m.IsReject(v.current())
+}
+
+// step takes one step, and returns false if the machine halted or errored.
+func (v *viewer) step() bool {
+	if v.halted() || v.err != nil {
+		return false
+	}
+	conf := v.current()
+	fired := firedTransition(v.m, conf)
+	next, err := v.m.Step(conf)
+	if err != nil {
+		v.err = err
+		return false
+	}
+	v.history = append(v.history, frame{next, fired})
+	return true
+}
+
+func (v *viewer) back() {
+	if v.err != nil {
+		v.err = nil
+		return
+	}
+	if len(v.history) > 1 {
+		v.history = v.history[:len(v.history)-1]
+	}
+}
+
+// firedTransition finds the transition a deterministic machine follows from the configuration, as it was written,
+// or nil if the machine is nondeterministic or cannot describe itself.
+func firedTransition(m machine.Machine, conf machine.Configuration) []string {
+	d, ok := m.(machine.Describer)
+	if !ok {
+		return nil
+	}
+	wildcards := false
+	switch d.Type() {
+	case machine.DFA:
+	case machine.ONE_WAY_TM, machine.TWO_WAY_TM, machine.MULTI_TAPE_TM:
+		wildcards = true
+	default:
+		return nil
+	}
+
+	read := reading(m, conf)
+	if len(read) != 1 {
+		return nil
+	}
+	// the first transition that matches is followed
+	for _, t := range d.Transitions() {
+		if len(t) < len(read[0]) {
+			continue
+		}
+		matches := true
+		for i, r := range read[0] {
+			if t[i] != r && !(wildcards && t[i] == machine.Wildcard) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return t
+		}
+	}
+	return nil
+}
+
+// draw clears the screen and draws the simulation.
+func (v *viewer) draw() {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+	lines := v.frame(width)
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	// the terminal is raw, so each line has to return the cursor too
+	fmt.Print(clearScreen + strings.Join(lines, "\r\n"))
+}
+
+// frame returns the lines of the screen for a terminal that is width characters wide.
+func (v *viewer) frame(width int) []string {
+	conf := v.current()
+	lines := []string{
+		bold + "tint" + resetGraphics + "  " + v.name,
+		"Input: " + v.input,
+		"",
+	}
+
+	status := "Paused"
+	switch {
+	case v.err != nil:
+		status = "ERROR! " + v.err.Error()
+	case v.m.IsAccept(conf):
+		status = "Accepted."
+	case v.m.IsReject(conf):
+		status = "Rejected."
+	case v.playing:
+		status = "Playing"
+	}
+	lines = append(lines, fmt.Sprintf("Step: %-8d Speed: %g steps/s   %s", len(v.history)-1, float64(time.Second)/float64(v.delay), status))
+
+	tc, ok := conf.(machine.TapeConfiguration)
+	if !ok {
+		// the machine cannot show its tapes, so it is drawn the same way -v prints it
+		lines = append(lines, "")
+		return append(append(lines, strings.Split(conf.Print(), "\n")...), "", tuiControls)
+	}
+
+	lines = append(lines, "State: "+bold+tc.State()+resetGraphics)
+	fired := "Transition: "
+	if t := v.history[len(v.history)-1].fired; t != nil {
+		fired += "[" + strings.Join(t, ", ") + "]"
+	}
+	lines = append(lines, fired, "")
+
+	// only a Turing machine has blanks past the end of its tape
+	blank := turing.Blank
+	if d, ok := v.m.(machine.Describer); ok && d.Type() == machine.DFA {
+		blank = " "
+	}
+	tapes, heads := tc.Tapes()
+	for i := range tapes {
+		cells, caret := drawTape(tapes[i], heads[i], blank, width)
+		lines = append(lines, cells, caret)
+	}
+	return append(lines, "", tuiControls)
+}
+
+// drawTape draws the cells of a tape around the head, fitting in width characters,
+// with the head highlighted and a caret under it.
+// The cells past the end of the tape have the blank in them.
+// A cell is measured in characters, not bytes, so symbols like □ or α line up with the caret.
+func drawTape(tape []string, head int, blank string, width int) (string, string) {
+	size := utf8.RuneCountInString
+	cell := func(i int) string {
+		symbol := blank
+		if i >= 0 && i < len(tape) {
+			symbol = tape[i]
+		}
+		return " " + symbol + " "
+	}
+
+	// a Turing machine's tape is drawn with one blank past its end
+	end := len(tape) - 1
+	if blank == turing.Blank {
+		end = len(tape)
+	}
+
+	// widens the window around the head one cell at a time, keeping the head in the middle,
+	// leaving room for the character before the first cell, and the | and … after the last
+	first, last := head, head
+	used := size(cell(head)) + 1
+	for grew := true; grew; {
+		grew = false
+		if first > 0 && used+size(cell(first-1))+1 <= width-3 {
+			first--
+			used += size(cell(first)) + 1
+			grew = true
+		}
+		if last < end && used+size(cell(last+1))+1 <= width-3 {
+			last++
+			used += size(cell(last)) + 1
+			grew = true
+		}
+	}
+
+	var cells, caret strings.Builder
+	if first > 0 {
+		cells.WriteString("…")
+	} else {
+		cells.WriteString(" ")
+	}
+	caret.WriteString(" ")
+	for i := first; i <= last; i++ {
+		cells.WriteString("|")
+		caret.WriteString(" ")
+		c := cell(i)
+		if i == head {
+			cells.WriteString(reverseVideo + c + resetGraphics)
+			caret.WriteString(strings.Repeat(" ", (size(c)-1)/2) + "^")
+		} else {
+			cells.WriteString(c)
+			caret.WriteString(strings.Repeat(" ", size(c)))
+		}
+	}
+	cells.WriteString("|")
+	if last < len(tape)-1 {
+		cells.WriteString("…")
+	}
+	return cells.String(), strings.TrimRight(caret.String(), " ")
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// drawTapeT
+type drawTapeT struct {
+	tape  []string
+	head  int
+	blank string
+	width int
+}
+
+var drawTapeTests []drawTapeT
+
+// TestDrawTape checks the tape fits in the width, and the caret is under the middle of the head's cell,
+// counting characters instead of bytes.
+func TestDrawTape(t *testing.T) {
+	for _, tc := range drawTapeTests {
+		cells, caret := drawTape(tc.tape, tc.head, tc.blank, tc.width)
+		cells = strings.NewReplacer(reverseVideo, "", resetGraphics, "").Replace(cells)
+
+		if n := utf8.RuneCountInString(cells); n > tc.width {
+			t.Errorf("drawTape(%v, %d) is %d characters wide, wider than %d:\n%s", tc.tape, tc.head, n, tc.width, cells)
+		}
+
+		// the head's cell is the one the caret is under
+		at := utf8.RuneCountInString(caret) - 1
+		under := []rune(cells)[at]
+		if string(under) != tc.tape[tc.head] {
+			t.Errorf("drawTape(%v, %d) has the caret under %q, not %q:\n%s\n%s", tc.tape, tc.head, under, tc.tape[tc.head], cells, caret)
+		}
+	}
+}
+
+func init() {
+	drawTapeTests = []drawTapeT{
+		{[]string{"a", "b", "c"}, 1, "_", 80},
+		{[]string{"□", "α", "b"}, 1, "□", 80},
+		{[]string{"α", "β", "γ", "δ"}, 3, "_", 80},
+		{strings.Fields(strings.Repeat("α β γ □ ", 20)), 40, "_", 30},
+		{strings.Fields(strings.Repeat("α β γ □ ", 20)), 0, "_", 30},
+		{strings.Fields(strings.Repeat("α β γ □ ", 20)), 79, "_", 30},
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cjcodell1/tint/validate"
+)
+
+// validateCommand prints the problems found in a machine without simulating it.
+// Exits with 1 if there are any errors.
+//
+//	tint validate -m MACHINE_TYPE MACHINE_FILE
+func validateCommand(args []string) {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var machineType string
+	flags.StringVar(&machineType, "machine", "", "denote what type of machine is specified")
+	flags.StringVar(&machineType, "m", "", "denote what type of machine is specified (short-hand)")
+	flags.Parse(args)
+
+	// Ensures there is one non-flag argument.
+	if flags.NArg() != 1 {
+		flags.PrintDefaults()
+		fmt.Println("Please provide the machine to validate.")
+		os.Exit(1)
+	}
+
+	problems, err := validate.Validate(describe(buildMachine(flags.Arg(0), machineType)))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	errors, warnings := 0, 0
+	for _, p := range problems {
+		fmt.Println(p)
+		if p.Severity == validate.Error {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	if len(problems) > 0 {
+		fmt.Println()
+	}
+	fmt.Printf("%d errors.\n", errors)
+	fmt.Printf("%d warnings.\n", warnings)
+
+	if errors > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,67 @@
+// Package export writes machines in formats that other programs can read.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// DOT writes the machine as a Graphviz DOT graph of its state diagram.
+// The start state has an arrow pointing to it, accept states are double circles,
+// reject states are octagons, and parallel transitions are merged into one edge.
+func DOT(w io.Writer, d machine.Describer) error {
+	var graph strings.Builder
+
+	// the WriteString method on a strings.Builder always returns a nil error.
+	graph.WriteString("// " + d.Type() + "\n")
+	graph.WriteString("digraph machine {\n")
+	graph.WriteString("\trankdir=LR;\n")
+	graph.WriteString("\tnode [shape=circle];\n")
+	graph.WriteString("\t__start [shape=none, label=\"\"];\n")
+
+	// the states, with the accept and reject states drawn differently
+	shapes := make(map[string]string)
+	for _, state := range d.RejectStates() {
+		shapes[state] = "octagon"
+	}
+	for _, state := range d.AcceptStates() {
+		shapes[state] = "doublecircle"
+	}
+	for _, state := range d.States() {
+		if shape, ok := shapes[state]; ok {
+			graph.WriteString(fmt.Sprintf("\t%s [shape=%s];\n", strconv.Quote(state), shape))
+		} else {
+			graph.WriteString(fmt.Sprintf("\t%s;\n", strconv.Quote(state)))
+		}
+	}
+
+	// the start arrow
+	graph.WriteString(fmt.Sprintf("\t__start -> %s;\n", strconv.Quote(d.StartState())))
+
+	// the transitions, merging the labels of every edge between the same two states
+	order := [][2]string{}
+	labels := make(map[[2]string][]string)
+	for _, edge := range d.Edges() {
+		if len(edge) != 3 {
+			return fmt.Errorf("%v is not an edge, it must be [from, to, label]", edge)
+		}
+		key := [2]string{edge[0], edge[1]}
+		if _, ok := labels[key]; !ok {
+			order = append(order, key)
+		}
+		labels[key] = append(labels[key], edge[2])
+	}
+	for _, key := range order {
+		label := strings.Join(labels[key], "\n")
+		graph.WriteString(fmt.Sprintf("\t%s -> %s [label=%s];\n", strconv.Quote(key[0]), strconv.Quote(key[1]), strconv.Quote(label)))
+	}
+
+	graph.WriteString("}\n")
+
+	_, err := io.WriteString(w, graph.String())
+	return err
+}
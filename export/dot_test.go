@@ -0,0 +1,101 @@
+package export_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cjcodell1/tint/export"
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/ways/one"
+)
+
+type dotTest struct {
+	m      machine.Machine
+	name   string
+	expect string
+}
+
+var mod2DFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"even", "a", "odd"},
+		{"even", "b", "odd"},
+		{"odd", "a", "even"},
+		{"odd", "b", "even"},
+	},
+	"even",
+	[]string{"even"})
+
+var epsilonNFA, _ = nfa.MakeNFA(
+	[][]string{
+		{"q0", machine.Epsilon, "q1"},
+		{"q1", "a", "q1"},
+	},
+	"q0",
+	[]string{"q1"})
+
+var starTM, _ = one.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", "q0", "x", turing.Right},
+		{"q0", turing.Blank, "yes", turing.Blank, turing.Left},
+		{"*", "b", "no", "*", turing.Right},
+	},
+	"q0",
+	"yes",
+	"no")
+
+var dotTests = []dotTest{
+	{mod2DFA, "mod2DFA", `// dfa
+digraph machine {
+	rankdir=LR;
+	node [shape=circle];
+	__start [shape=none, label=""];
+	"even" [shape=doublecircle];
+	"odd";
+	__start -> "even";
+	"even" -> "odd" [label="a\nb"];
+	"odd" -> "even" [label="a\nb"];
+}
+`},
+	{epsilonNFA, "epsilonNFA", `// nfa
+digraph machine {
+	rankdir=LR;
+	node [shape=circle];
+	__start [shape=none, label=""];
+	"q0";
+	"q1" [shape=doublecircle];
+	__start -> "q0";
+	"q0" -> "q1" [label="ε"];
+	"q1" -> "q1" [label="a"];
+}
+`},
+	{starTM, "starTM", `// one-way-tm
+digraph machine {
+	rankdir=LR;
+	node [shape=circle];
+	__start [shape=none, label=""];
+	"q0";
+	"yes" [shape=doublecircle];
+	"no" [shape=octagon];
+	__start -> "q0";
+	"q0" -> "q0" [label="a→x,R"];
+	"q0" -> "yes" [label="_→_,L"];
+	"q0" -> "no" [label="b→*,R"];
+}
+`},
+}
+
+func TestDOT(t *testing.T) {
+	for _, tc := range dotTests {
+		var got strings.Builder
+		err := export.DOT(&got, tc.m.(machine.Describer))
+		if err != nil {
+			t.Errorf("DOT(%s) errored with %s", tc.name, err)
+		}
+		if got.String() != tc.expect {
+			t.Errorf("DOT(%s) ==\n%s\n!=\n%s", tc.name, got.String(), tc.expect)
+		}
+	}
+}
@@ -41,20 +41,23 @@ func ReadLines(path string) ([]string, error) {
 	// Reads until EOF or error
 	for {
 		line, err := bufReaderPtr.ReadString(byte('\n'))
-		if err == io.EOF {
-			return lines, nil
-		}
-		if err != nil {
+		if err != nil && err != io.EOF {
 			return lines, err
 		}
 
+		// the last line has no \n if the file does not end with one
+		if err == io.EOF && line == "" {
+			return lines, nil
+		}
+
 		line = strings.TrimSuffix(line, "\n")
 
 		// remove the \r if file has DOS endings
 		line = strings.TrimSuffix(line, "\r")
 
 		lines = append(lines, line)
+		if err == io.EOF {
+			return lines, nil
+		}
 	}
-
-	return lines, nil
 }
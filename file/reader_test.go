@@ -24,6 +24,7 @@ var readAllTests = []readAllTest{
 	{"examples/file7", "\n\n", true},
 	{"examples/file8", "hello\r\nworld\r\n", true}, // dos endings
 	{"examples/file9", "\r\n", true},               // also dos endings
+	{"examples/file11", "a\nb", true},              // no newline at the end
 }
 
 func TestReadAll(t *testing.T) {
@@ -63,6 +64,7 @@ var readLinesTests = []readLinesTest{
 	{"examples/file7", []string{"", ""}, true},
 	{"examples/file8", []string{"hello", "world"}, true}, // dos endings
 	{"examples/file9", []string{""}, true},               // also dos endings
+	{"examples/file11", []string{"a", "b"}, true},        // no newline at the end
 }
 
 func TestReadLines(t *testing.T) {
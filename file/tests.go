@@ -0,0 +1,43 @@
+package file
+
+import (
+	"strings"
+)
+
+// The outcomes a test can expect.
+const (
+	Accept = "accept"
+	Reject = "reject"
+)
+
+// Test is a single test from a test file.
+type Test struct {
+	Input  string
+	Expect string // Accept, Reject, or the empty string when the test does not expect an outcome
+}
+
+// ParseTest reads a single test from a line.
+// A line starting with "accept:" or "reject:" expects that outcome for the rest of the line,
+// any other line is a bare input.
+func ParseTest(line string) Test {
+	for _, expect := range []string{Accept, Reject} {
+		if strings.HasPrefix(line, expect+":") {
+			return Test{strings.TrimSpace(strings.TrimPrefix(line, expect+":")), expect}
+		}
+	}
+	return Test{line, ""}
+}
+
+// ReadTests reads an entire file of tests from a path, with one test on each line.
+func ReadTests(path string) ([]Test, error) {
+	lines, err := ReadLines(path)
+	if err != nil {
+		return []Test{}, err
+	}
+
+	tests := make([]Test, 0, len(lines))
+	for _, line := range lines {
+		tests = append(tests, ParseTest(line))
+	}
+	return tests, nil
+}
@@ -0,0 +1,60 @@
+package file_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/file"
+)
+
+type parseTestTest struct {
+	line   string
+	expect file.Test
+}
+
+var parseTestTests = []parseTestTest{
+	{"a b c", file.Test{Input: "a b c"}},
+	{"", file.Test{Input: ""}},
+	{"accept: a b c", file.Test{Input: "a b c", Expect: file.Accept}},
+	{"reject: a b c", file.Test{Input: "a b c", Expect: file.Reject}},
+	{"accept:a", file.Test{Input: "a", Expect: file.Accept}},
+	{"reject:", file.Test{Input: "", Expect: file.Reject}},
+	{"a accept: b", file.Test{Input: "a accept: b"}},
+	{"accepted: a", file.Test{Input: "accepted: a"}},
+}
+
+func TestParseTest(t *testing.T) {
+	for _, tc := range parseTestTests {
+		got := file.ParseTest(tc.line)
+		if got != tc.expect {
+			t.Errorf("ParseTest(%q) == %+v != %+v", tc.line, got, tc.expect)
+		}
+	}
+}
+
+type readTestsTest struct {
+	path     string
+	expect   []file.Test
+	isErrNil bool
+}
+
+var readTestsTests = []readTestsTest{
+	{"examples/file3", []file.Test{{Input: "a"}, {Input: "b"}, {Input: "c"}}, true},
+	{"examples/file6", []file.Test{}, false}, // file does not exist
+	{"examples/file10", []file.Test{
+		{Input: "a b", Expect: file.Accept},
+		{Input: "a a", Expect: file.Reject},
+		{Input: ""},
+		{Input: "", Expect: file.Reject},
+		{Input: "b accept: c"},
+	}, true},
+}
+
+func TestReadTests(t *testing.T) {
+	for _, tc := range readTestsTests {
+		got, gotErr := file.ReadTests(tc.path)
+		if (fmt.Sprint(got) != fmt.Sprint(tc.expect)) || (tc.isErrNil != (gotErr == nil)) {
+			t.Errorf("ReadTests(%s) == %+v, %v != %+v, nil error is %t", tc.path, got, gotErr, tc.expect, tc.isErrNil)
+		}
+	}
+}
@@ -15,3 +15,13 @@ type Configuration interface {
 	// Gets the important information from a Configuration to find/perform the next Transition
 	GetNext() ([]string, error)
 }
+
+// interface for Configurations of Machines that read from tapes, so the tapes can be drawn
+type TapeConfiguration interface {
+	Configuration
+	// Returns the state.
+	State() string
+	// Returns the symbols on each tape, and where the head is on each tape.
+	// A head can be past the end of its tape, where it reads a blank.
+	Tapes() ([][]string, []int)
+}
@@ -0,0 +1,24 @@
+package machine
+
+// States returns every state of a Describer, in the order they are first mentioned:
+// the start state, the states each edge [from, to, label] is from and to, and then the others.
+func States(start string, edges [][]string, others ...string) []string {
+	states := []string{}
+	seen := make(map[string]bool)
+	add := func(state string) {
+		if !seen[state] {
+			seen[state] = true
+			states = append(states, state)
+		}
+	}
+
+	add(start)
+	for _, edge := range edges {
+		add(edge[0])
+		add(edge[1])
+	}
+	for _, state := range others {
+		add(state)
+	}
+	return states
+}
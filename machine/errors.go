@@ -0,0 +1,33 @@
+// Package for all machines.
+package machine
+
+import "fmt"
+
+// TransitionError is an error with one of the transitions a Machine was made with.
+type TransitionError struct {
+	Index int // the index of the transition, counted from 0
+	Err   error
+}
+
+// Error writes the error, with the transition counted from 1 like the lines of a list.
+func (e TransitionError) Error() string {
+	return fmt.Sprintf("transition %d: %s", e.Index+1, e.Err)
+}
+
+func (e TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError is an error with one of the other values a Machine was made with, e.g. the reject state.
+type FieldError struct {
+	Field string // the name of the value, as it is written in a machine file, e.g. "reject"
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
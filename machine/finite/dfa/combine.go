@@ -0,0 +1,103 @@
+package dfa
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// Union builds a DFA that accepts what either DFA accepts.
+func Union(m1 machine.Machine, m2 machine.Machine) (machine.Machine, error) {
+	return product(m1, m2, func(a1 bool, a2 bool) bool { return a1 || a2 })
+}
+
+// Intersect builds a DFA that accepts what both DFAs accept.
+func Intersect(m1 machine.Machine, m2 machine.Machine) (machine.Machine, error) {
+	return product(m1, m2, func(a1 bool, a2 bool) bool { return a1 && a2 })
+}
+
+// Difference builds a DFA that accepts what the first DFA accepts and the second does not.
+func Difference(m1 machine.Machine, m2 machine.Machine) (machine.Machine, error) {
+	return product(m1, m2, func(a1 bool, a2 bool) bool { return a1 && !a2 })
+}
+
+// Complement builds a DFA that accepts what the DFA rejects, over the symbols of its transitions.
+// Missing transitions go to a trap state first, which the complement accepts in.
+// Only the states reachable from the start state are kept.
+func Complement(m machine.Machine) (machine.Machine, error) {
+	d, ok := m.(dfa)
+	if !ok {
+		return nil, errors.New("Only a DFA can be complemented.")
+	}
+	t := d.table()
+
+	trans := [][]string{}
+	accepts := []string{}
+	for _, state := range t.states {
+		for _, symbol := range t.alphabet {
+			trans = append(trans, []string{state, symbol, t.delta[state][symbol]})
+		}
+		if !t.accepts[state] {
+			accepts = append(accepts, state)
+		}
+	}
+
+	return MakeDFA(trans, t.start, accepts)
+}
+
+// product builds the product of two DFAs, which accepts when accept does for whether each DFA accepts.
+// The states are pairs of states, named like (p,q) or numbered like (p,q)1 if the name is taken, and only those reachable from the start state are built.
+// The symbols are those of both DFAs, and a symbol that only one DFA reads sends the other to a trap state.
+func product(m1 machine.Machine, m2 machine.Machine, accept func(bool, bool) bool) (machine.Machine, error) {
+	d1, ok1 := m1.(dfa)
+	d2, ok2 := m2.(dfa)
+	if !ok1 || !ok2 {
+		return nil, errors.New("Only DFAs can be combined.")
+	}
+	t1, t2 := d1.table(), d2.table()
+
+	alphabet := t1.union(t2)
+
+	type pair [2]string
+	// a state with a comma or parenthesis in it can make two pairs look the same, e.g. (a,b,c),
+	// so a pair is numbered like (a,b,c)1 when another pair already has its name
+	names := map[pair]string{}
+	taken := map[string]bool{}
+	name := func(p pair) string {
+		if n, ok := names[p]; ok {
+			return n
+		}
+		n := "(" + p[0] + "," + p[1] + ")"
+		for i := 1; taken[n]; i++ {
+			n = "(" + p[0] + "," + p[1] + ")" + strconv.Itoa(i)
+		}
+		names[p] = n
+		taken[n] = true
+		return n
+	}
+
+	start := pair{t1.start, t2.start}
+	seen := map[pair]bool{start: true}
+	queue := []pair{start}
+	trans := [][]string{}
+	accepts := []string{}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if accept(t1.accepts[p[0]], t2.accepts[p[1]]) {
+			accepts = append(accepts, name(p))
+		}
+		for _, symbol := range alphabet {
+			next := pair{t1.next(p[0], symbol), t2.next(p[1], symbol)}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+			trans = append(trans, []string{name(p), symbol, name(next)})
+		}
+	}
+
+	return MakeDFA(trans, name(start), accepts)
+}
@@ -0,0 +1,122 @@
+package dfa_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+type combineT struct {
+	op      func(machine.Machine, machine.Machine) (machine.Machine, error)
+	d1      machine.Machine
+	d2      machine.Machine
+	name    string
+	accepts []string
+	rejects []string
+}
+
+var combineTests []combineT
+
+// run simulates a DFA until it halts.
+func run(m machine.Machine, input string) (bool, error) {
+	conf := m.Start(input)
+	for !m.IsAccept(conf) && !m.IsReject(conf) {
+		var err error
+		conf, err = m.Step(conf)
+		if err != nil {
+			return false, err
+		}
+	}
+	return m.IsAccept(conf), nil
+}
+
+func TestCombine(t *testing.T) {
+	for _, tc := range combineTests {
+		m, err := tc.op(tc.d1, tc.d2)
+		if err != nil {
+			t.Errorf("%s == %s", tc.name, err)
+			continue
+		}
+		for _, input := range tc.accepts {
+			if got, err := run(m, input); !got || err != nil {
+				t.Errorf("%s on %q == %t, %v != true, nil", tc.name, input, got, err)
+			}
+		}
+		for _, input := range tc.rejects {
+			if got, err := run(m, input); got || err != nil {
+				t.Errorf("%s on %q == %t, %v != false, nil", tc.name, input, got, err)
+			}
+		}
+	}
+}
+
+func TestProductStates(t *testing.T) {
+	m, _ := dfa.Intersect(evenDFA, abDFA)
+	d := m.(machine.Describer)
+	expect := "(even,q0) [] [(even,q0) (odd,q1) (even,trap) (odd,q2) (odd,trap)]"
+	if got := fmt.Sprintf("%s %v %v", d.StartState(), d.AcceptStates(), d.States()); got != expect {
+		t.Errorf("Intersect(evenDFA, abDFA) == %s != %s", got, expect)
+	}
+}
+
+// TestProductCommas checks two pairs that would have the same name, (a,b,c), are still different states.
+func TestProductCommas(t *testing.T) {
+	d1, _ := dfa.MakeDFA([][]string{{"s", "x", "a,b"}, {"s", "y", "a"}}, "s", []string{"a,b"})
+	d2, _ := dfa.MakeDFA([][]string{{"t", "x", "c"}, {"t", "y", "b,c"}}, "t", []string{"c"})
+	m, err := dfa.Intersect(d1, d2)
+	if err != nil {
+		t.Fatalf("Intersect(d1, d2) == %s", err)
+	}
+
+	d := m.(machine.Describer)
+	expect := "[(a,b,c)] [(s,t) (a,b,c) (a,b,c)1 (trap,trap)]"
+	if got := fmt.Sprintf("%v %v", d.AcceptStates(), d.States()); got != expect {
+		t.Errorf("Intersect(d1, d2) == %s != %s", got, expect)
+	}
+	if got, err := run(m, "x"); !got || err != nil {
+		t.Errorf("Intersect(d1, d2) on \"x\" == %t, %v != true, nil", got, err)
+	}
+	if got, err := run(m, "y"); got || err != nil {
+		t.Errorf("Intersect(d1, d2) on \"y\" == %t, %v != false, nil", got, err)
+	}
+}
+
+func TestCombineNotDFA(t *testing.T) {
+	if _, err := dfa.Union(nil, mod4DFA); err == nil {
+		t.Error("Union(nil, mod4DFA) did not error.")
+	}
+}
+
+func TestComplement(t *testing.T) {
+	m, err := dfa.Complement(abDFA)
+	if err != nil {
+		t.Fatalf("Complement(abDFA) == %s", err)
+	}
+	for input, expect := range map[string]bool{"": true, "a": true, "a b": false, "a b a": true, "b": true} {
+		if got, err := run(m, input); got != expect || err != nil {
+			t.Errorf("Complement(abDFA) on %q == %t, %v != %t, nil", input, got, err, expect)
+		}
+	}
+
+	if _, err := dfa.Complement(nil); err == nil {
+		t.Error("Complement(nil) did not error.")
+	}
+}
+
+func init() {
+	combineTests = []combineT{
+		{dfa.Union, evenDFA, endsABDFA, "Union(evenDFA, endsABDFA)",
+			[]string{"", "a b", "a a", "b a b"}, []string{"a", "b a"}},
+		{dfa.Intersect, evenDFA, endsABDFA, "Intersect(evenDFA, endsABDFA)",
+			[]string{"a a b", "a b a b"}, []string{"", "a b", "a a"}},
+		{dfa.Difference, evenDFA, endsABDFA, "Difference(evenDFA, endsABDFA)",
+			[]string{"", "a a", "b b"}, []string{"a a b", "a"}},
+		// abDFA is missing transitions, and redLightDFA has different symbols
+		{dfa.Union, abDFA, redLightDFA, "Union(abDFA, redLightDFA)",
+			[]string{"a b", "r", "r g y"}, []string{"", "a", "a r", "r r"}},
+		{dfa.Intersect, abDFA, mod4DFA, "Intersect(abDFA, mod4DFA)",
+			[]string{}, []string{"", "a b", "a a"}},
+	}
+}
@@ -26,6 +26,16 @@ func (conf config) IsState(state string) bool {
 	return conf.state == state
 }
 
+// State returns the state.
+func (conf config) State() string {
+	return conf.state
+}
+
+// Tapes returns the input that is left to read as the only tape, with the head at its start.
+func (conf config) Tapes() ([][]string, []int) {
+	return [][]string{conf.input}, []int{0}
+}
+
 func (conf config) CanNext() bool {
 	return len(conf.input) != 0
 }
@@ -40,11 +50,8 @@ func (conf config) Next(inputs []string) (machine.Configuration, error) {
 		return conf, nil
 	}
 
-	// don't want to mutate
-	prevInput := make([]string, len(conf.input))
-	copy(prevInput, conf.input)
-
-	return config{inputs[0], prevInput[1:]}, nil
+	// the input is never written to, so the next configuration can share it
+	return config{inputs[0], conf.input[1:]}, nil
 }
 
 func (conf config) GetNext() ([]string, error) {
@@ -0,0 +1,44 @@
+package dfa
+
+import (
+	"github.com/cjcodell1/tint/machine"
+)
+
+func (d dfa) Type() string {
+	return machine.DFA
+}
+
+func (d dfa) States() []string {
+	return machine.States(d.start, d.Edges(), d.accepts...)
+}
+
+func (d dfa) StartState() string {
+	return d.start
+}
+
+func (d dfa) AcceptStates() []string {
+	return append([]string{}, d.accepts...)
+}
+
+// A DFA rejects in any state that is not an accept state, so there are no reject states.
+func (d dfa) RejectStates() []string {
+	return []string{}
+}
+
+// output: [[state, symbol, state], ...]
+func (d dfa) Transitions() [][]string {
+	trans := make([][]string, 0, len(d.trans))
+	for _, t := range d.trans {
+		trans = append(trans, append(t.GetInput(), t.GetOutput()...))
+	}
+	return trans
+}
+
+// output: [[from, to, symbol], ...]
+func (d dfa) Edges() [][]string {
+	edges := make([][]string, 0, len(d.trans))
+	for _, t := range d.trans {
+		edges = append(edges, []string{t.in.state, t.out.state, t.in.symbol})
+	}
+	return edges
+}
@@ -0,0 +1,62 @@
+package dfa
+
+import (
+	"errors"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// Counterexample is an input that two DFAs disagree on.
+type Counterexample struct {
+	Input        []string // the symbols of the input
+	FirstAccepts bool     // true if the first DFA accepts the input, and false if the second does
+}
+
+// Equivalent checks if two DFAs recognize the same language, by searching the product of the DFAs
+// breadth-first for a pair of states where only one accepts.
+// A symbol that only one DFA reads sends the other to a trap state.
+//
+// Output: nil if the DFAs are equivalent, or else the shortest input they disagree on.
+// Of the shortest inputs, it is the first in the order the symbols first appear in the transitions.
+func Equivalent(m1 machine.Machine, m2 machine.Machine) (*Counterexample, error) {
+	d1, ok1 := m1.(dfa)
+	d2, ok2 := m2.(dfa)
+	if !ok1 || !ok2 {
+		return nil, errors.New("Only DFAs can be checked for equivalence.")
+	}
+	t1, t2 := d1.table(), d2.table()
+
+	alphabet := t1.union(t2)
+
+	// each pair remembers the pair and symbol it was reached from, to rebuild the input
+	type pair [2]string
+	type from struct {
+		pair   pair
+		symbol string
+	}
+	start := pair{t1.start, t2.start}
+	parents := map[pair]from{start: {}}
+	queue := []pair{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if t1.accepts[p[0]] != t2.accepts[p[1]] {
+			input := []string{}
+			for at := p; at != start; at = parents[at].pair {
+				input = append([]string{parents[at].symbol}, input...)
+			}
+			return &Counterexample{input, t1.accepts[p[0]]}, nil
+		}
+
+		for _, symbol := range alphabet {
+			next := pair{t1.next(p[0], symbol), t2.next(p[1], symbol)}
+			if _, ok := parents[next]; !ok {
+				parents[next] = from{p, symbol}
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil, nil
+}
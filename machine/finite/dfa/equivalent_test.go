@@ -0,0 +1,81 @@
+package dfa_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+type equivalentT struct {
+	d1     machine.Machine
+	d2     machine.Machine
+	name   string
+	expect string
+}
+
+var equivalentTests []equivalentT
+
+func TestEquivalent(t *testing.T) {
+	for _, tc := range equivalentTests {
+		got, err := dfa.Equivalent(tc.d1, tc.d2)
+		if err != nil {
+			t.Errorf("Equivalent(%s) == %s", tc.name, err)
+			continue
+		}
+		actual := "equivalent"
+		if got != nil {
+			actual = fmt.Sprint(*got)
+		}
+		if actual != tc.expect {
+			t.Errorf("Equivalent(%s) == %s != %s", tc.name, actual, tc.expect)
+		}
+	}
+}
+
+func TestEquivalentNotDFA(t *testing.T) {
+	_, err := dfa.Equivalent(mod2DFA, nil)
+	if err == nil {
+		t.Error("Equivalent(mod2DFA, nil) did not error.")
+	}
+}
+
+// accepts an even number of a's
+var evenDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"even", "a", "odd"},
+		{"even", "b", "even"},
+		{"odd", "a", "even"},
+		{"odd", "b", "odd"},
+	},
+	"even",
+	[]string{"even"})
+
+// accepts any string of a's and b's that ends with "a b"
+var endsABDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "q1"},
+		{"q0", "b", "q0"},
+		{"q1", "a", "q1"},
+		{"q1", "b", "q2"},
+		{"q2", "a", "q1"},
+		{"q2", "b", "q0"},
+	},
+	"q0",
+	[]string{"q2"})
+
+func init() {
+	minimal, _, _ := dfa.Minimize(mod4DFA)
+
+	equivalentTests = []equivalentT{
+		{mod4DFA, evenDFA, "mod4DFA, evenDFA", "equivalent"},
+		{minimal, mod4DFA, "Minimize(mod4DFA), mod4DFA", "equivalent"},
+		{evenDFA, endsABDFA, "evenDFA, endsABDFA", "{[] true}"},
+		{endsABDFA, evenDFA, "endsABDFA, evenDFA", "{[] false}"},
+		{abDFA, endsABDFA, "abDFA, endsABDFA", "{[a a b] false}"},
+		{emptyDFA, allDFA, "emptyDFA, allDFA", "{[] false}"},
+		// a symbol only one of them reads goes to a trap state
+		{redLightDFA, abDFA, "redLightDFA, abDFA", "{[r] true}"},
+	}
+}
@@ -10,21 +10,26 @@ import (
 
 type dfa struct {
 	trans   []transition
+	delta   map[input]output // the first transition in trans for each input
 	start   string
 	accepts []string
 }
 
 func MakeDFA(trans [][]string, start string, accepts []string) (machine.Machine, error) {
 	transitions := []transition{}
-	for _, tran := range trans {
+	delta := map[input]output{}
+	for i, tran := range trans {
 		t, err := makeTransition(tran)
 		if err != nil {
-			return nil, err
+			return nil, machine.TransitionError{Index: i, Err: err}
 		}
 		transitions = append(transitions, t)
+		if _, ok := delta[t.in]; !ok {
+			delta[t.in] = t.out
+		}
 	}
 
-	return dfa{transitions, start, accepts}, nil
+	return dfa{transitions, delta, start, accepts}, nil
 }
 
 func (d dfa) Start(input string) machine.Configuration {
@@ -82,16 +87,10 @@ func (d dfa) IsReject(conf machine.Configuration) bool {
 }
 
 func (d dfa) findTransition(state string, symbol string) (string, error) {
-	for _, trans := range d.trans {
-		ans, err := trans.IsInput([]string{state, symbol})
-		if err != nil {
-			return "", err
-		}
-		if ans {
-			output := trans.GetOutput()
-			return output[0], nil
-		}
+	out, ok := d.delta[input{state, symbol}]
+	if !ok {
+		// no transition found
+		return "", fmt.Errorf("No transition found for state: \"%s\" and symbol \"%s\"", state, symbol)
 	}
-	// no transition found
-	return "", fmt.Errorf("No transition found for state: \"%s\" and symbol \"%s\"", state, symbol)
+	return out.state, nil
 }
@@ -2,6 +2,8 @@ package dfa_test
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/cjcodell1/tint/machine"
@@ -282,3 +284,24 @@ func init() {
 		{redLightDFA, "redLightDFA", step3, false},
 	}...)
 }
+
+// BenchmarkStep steps a DFA with 1000 states and 10 symbols, counting the digits of its input modulo 1000.
+func BenchmarkStep(b *testing.B) {
+	trans := [][]string{}
+	for q := 0; q < 1000; q++ {
+		for s := 0; s < 10; s++ {
+			trans = append(trans, []string{strconv.Itoa(q), strconv.Itoa(s), strconv.Itoa((q + s) % 1000)})
+		}
+	}
+	d, _ := dfa.MakeDFA(trans, "0", []string{"0"})
+	input := strings.Repeat("9 8 7 6 5 4 3 2 1 0 ", 100)
+
+	b.ResetTimer()
+	conf := d.Start(input)
+	for i := 0; i < b.N; i++ {
+		if !conf.CanNext() {
+			conf = d.Start(input)
+		}
+		conf, _ = d.Step(conf)
+	}
+}
@@ -0,0 +1,103 @@
+package dfa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// Minimize builds the DFA with the fewest states that recognizes the same language as the given DFA.
+// Its states are the equivalence classes of the reachable states, found with Moore's algorithm,
+// and each is named after the first of its states reached from the start state.
+// Missing transitions go to a trap state, so the minimal DFA has every transition.
+//
+// Output: the minimal DFA, and a map from each of its states to the states it merges.
+// A state that merges only the added trap state maps to an empty slice.
+func Minimize(m machine.Machine) (machine.Machine, map[string][]string, error) {
+	d, ok := m.(dfa)
+	if !ok {
+		return nil, nil, errors.New("Only a DFA can be minimized.")
+	}
+	t := d.table()
+
+	// start with the accept and non-accept states, then split the classes until none can be split
+	class := make(map[string]int)
+	count := refine(t.states, class, func(state string) string {
+		return fmt.Sprint(t.accepts[state])
+	})
+	for {
+		next := make(map[string]int)
+		n := refine(t.states, next, func(state string) string {
+			key := []int{class[state]}
+			for _, symbol := range t.alphabet {
+				key = append(key, class[t.delta[state][symbol]])
+			}
+			return fmt.Sprint(key)
+		})
+		class = next
+		if n == count {
+			break
+		}
+		count = n
+	}
+
+	// name each class after its first state, preferring any state to the trap state
+	names := make([]string, count)
+	for _, state := range t.states {
+		if state != t.trap && names[class[state]] == "" {
+			names[class[state]] = state
+		}
+	}
+	merged := make(map[string][]string)
+	for c, name := range names {
+		if name == "" {
+			names[c] = t.trap
+		}
+		merged[names[c]] = []string{}
+	}
+	for _, state := range t.states {
+		if state != t.trap {
+			merged[names[class[state]]] = append(merged[names[class[state]]], state)
+		}
+	}
+
+	trans := [][]string{}
+	accepts := []string{}
+	done := make(map[int]bool)
+	for _, state := range t.states {
+		c := class[state]
+		if done[c] {
+			continue
+		}
+		done[c] = true
+		for _, symbol := range t.alphabet {
+			trans = append(trans, []string{names[c], symbol, names[class[t.delta[state][symbol]]]})
+		}
+		if t.accepts[state] {
+			accepts = append(accepts, names[c])
+		}
+	}
+
+	min, err := MakeDFA(trans, names[class[t.start]], accepts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return min, merged, nil
+}
+
+// refine numbers the states by their keys, in the order each key is first seen.
+// Returns the number of different keys.
+func refine(states []string, class map[string]int, key func(string) string) int {
+	numbers := make(map[string]int)
+	for _, state := range states {
+		k := key(state)
+		n, ok := numbers[k]
+		if !ok {
+			n = len(numbers)
+			numbers[k] = n
+		}
+		class[state] = n
+	}
+	return len(numbers)
+}
@@ -0,0 +1,88 @@
+package dfa_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+type minimizeT struct {
+	d      machine.Machine
+	name   string
+	trans  string
+	merged string
+}
+
+var minimizeTests []minimizeT
+
+func TestMinimize(t *testing.T) {
+	for _, tc := range minimizeTests {
+		got, merged, err := dfa.Minimize(tc.d)
+		if err != nil {
+			t.Errorf("Minimize(%s) == %s", tc.name, err)
+			continue
+		}
+		d := got.(machine.Describer)
+		trans := fmt.Sprintf("%s %v %v", d.StartState(), d.AcceptStates(), d.Transitions())
+		if trans != tc.trans {
+			t.Errorf("Minimize(%s) == %s != %s", tc.name, trans, tc.trans)
+		}
+		if fmt.Sprint(merged) != tc.merged {
+			t.Errorf("Minimize(%s) merged %v != %s", tc.name, merged, tc.merged)
+		}
+	}
+}
+
+func TestMinimizeNotDFA(t *testing.T) {
+	_, _, err := dfa.Minimize(nil)
+	if err == nil {
+		t.Error("Minimize(nil) did not error.")
+	}
+}
+
+// accepts an even number of a's, but counts to four, and has an unreachable state
+var mod4DFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "q1"},
+		{"q1", "a", "q2"},
+		{"q2", "a", "q3"},
+		{"q3", "a", "q0"},
+		{"q0", "b", "q0"},
+		{"q1", "b", "q1"},
+		{"q2", "b", "q2"},
+		{"q3", "b", "q3"},
+		{"lost", "a", "q0"},
+	},
+	"q0",
+	[]string{"q0", "q2"})
+
+// accepts only "a b", and is missing transitions
+var abDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "q1"},
+		{"q1", "b", "q2"},
+	},
+	"q0",
+	[]string{"q2"})
+
+func init() {
+	minimizeTests = []minimizeT{
+		{mod2DFA, "mod2DFA",
+			"zero [zero] [[zero a zero]]",
+			"map[zero:[zero one]]"},
+		{mod4DFA, "mod4DFA",
+			"q0 [q0] [[q0 a q1] [q0 b q0] [q1 a q0] [q1 b q1]]",
+			"map[q0:[q0 q2] q1:[q1 q3]]"},
+		{abDFA, "abDFA",
+			"q0 [q2] [[q0 a q1] [q0 b trap] [q1 a trap] [q1 b q2] [trap a trap] [trap b trap] [q2 a trap] [q2 b trap]]",
+			"map[q0:[q0] q1:[q1] q2:[q2] trap:[]]"},
+		{emptyDFA, "emptyDFA",
+			"start [] [[start a start] [start b start] [start c start]]",
+			"map[start:[start reject]]"},
+		{redLightDFA, "redLightDFA",
+			"start [red yellow green] [[start r red] [start y yellow] [start g green] [red r error] [red y error] [red g green] [yellow r red] [yellow y error] [yellow g error] [green r error] [green y yellow] [green g error] [error r error] [error y error] [error g error]]",
+			"map[error:[error] green:[green] red:[red] start:[start] yellow:[yellow]]"},
+	}
+}
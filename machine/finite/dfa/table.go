@@ -0,0 +1,104 @@
+package dfa
+
+import (
+	"strconv"
+)
+
+// table is the complete transition function of a DFA over the states reachable from its start state.
+// A missing transition goes to a trap state, which is added only if one is missing.
+type table struct {
+	start    string
+	states   []string // in breadth-first order from the start state
+	alphabet []string // in the order the symbols first appear in the transitions
+	delta    map[string]map[string]string
+	accepts  map[string]bool
+	trap     string // the trap state, which is only one of the states if a transition was missing
+}
+
+// table builds the table of the DFA.
+// Like Step, the first transition for a state and symbol is the one followed.
+func (d dfa) table() table {
+	t := table{
+		start:   d.start,
+		delta:   make(map[string]map[string]string),
+		accepts: make(map[string]bool),
+	}
+
+	symbols := make(map[string]bool)
+	first := make(map[[2]string]string)
+	for _, trans := range d.trans {
+		if !symbols[trans.in.symbol] {
+			symbols[trans.in.symbol] = true
+			t.alphabet = append(t.alphabet, trans.in.symbol)
+		}
+		key := [2]string{trans.in.state, trans.in.symbol}
+		if _, ok := first[key]; !ok {
+			first[key] = trans.out.state
+		}
+	}
+	for _, state := range d.accepts {
+		t.accepts[state] = true
+	}
+
+	t.trap = trapName(d.States())
+	seen := map[string]bool{d.start: true}
+	queue := []string{d.start}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		t.states = append(t.states, state)
+		t.delta[state] = make(map[string]string)
+
+		for _, symbol := range t.alphabet {
+			next, ok := first[[2]string{state, symbol}]
+			if !ok {
+				next = t.trap
+			}
+			t.delta[state][symbol] = next
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return t
+}
+
+// next is the state after reading the symbol.
+// A state or symbol the table does not have goes to the trap state.
+func (t table) next(state string, symbol string) string {
+	if next, ok := t.delta[state][symbol]; ok {
+		return next
+	}
+	return t.trap
+}
+
+// union is the alphabet of both tables, in the order the symbols first appear in the first table, then the second.
+func (t table) union(other table) []string {
+	alphabet := append([]string{}, t.alphabet...)
+	seen := make(map[string]bool)
+	for _, symbol := range t.alphabet {
+		seen[symbol] = true
+	}
+	for _, symbol := range other.alphabet {
+		if !seen[symbol] {
+			seen[symbol] = true
+			alphabet = append(alphabet, symbol)
+		}
+	}
+	return alphabet
+}
+
+// trapName names a trap state that is not one of the given states.
+func trapName(states []string) string {
+	taken := make(map[string]bool)
+	for _, state := range states {
+		taken[state] = true
+	}
+	trap := "trap"
+	for i := 1; taken[trap]; i++ {
+		trap = "trap" + strconv.Itoa(i)
+	}
+	return trap
+}
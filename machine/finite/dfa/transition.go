@@ -2,6 +2,7 @@ package dfa
 
 import (
 	"errors"
+	"fmt"
 )
 
 type transition struct {
@@ -20,7 +21,7 @@ type output struct {
 
 func makeTransition(inputs []string) (transition, error) {
 	if len(inputs) != 3 {
-		return transition{}, errors.New("Illegal Transition.")
+		return transition{}, fmt.Errorf("A transition must have 3 values, not %d.", len(inputs))
 	}
 	return transition{input{inputs[0], inputs[1]}, output{inputs[2]}}, nil
 }
@@ -0,0 +1,64 @@
+package nfa
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// config holds every state the NFA could be in after reading the same prefix of the input.
+type config struct {
+	states []string // sorted and without duplicates
+	input  []string
+}
+
+func (conf config) Print() string {
+	var line strings.Builder
+
+	// the WriteString method on a strings.Builder always returns a nil error
+	line.WriteString("{")
+	line.WriteString(strings.Join(conf.states, ", "))
+	line.WriteString("}: ")
+	line.WriteString(strings.Join(conf.input, " "))
+	return line.String()
+}
+
+// IsState checks if the given state is one of the active states.
+func (conf config) IsState(state string) bool {
+	for _, s := range conf.states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// CanNext is false once the input is used up or there are no active states left.
+func (conf config) CanNext() bool {
+	return len(conf.input) != 0 && len(conf.states) != 0
+}
+
+// input: [states...], the active states after reading the next symbol
+func (conf config) Next(inputs []string) (machine.Configuration, error) {
+	// Don't step if you can't
+	if len(conf.input) == 0 {
+		return conf, nil
+	}
+
+	// don't want to mutate
+	nextStates := make([]string, len(inputs))
+	copy(nextStates, inputs)
+	prevInput := make([]string, len(conf.input))
+	copy(prevInput, conf.input)
+
+	return config{nextStates, prevInput[1:]}, nil
+}
+
+// output: [symbol, states...]
+func (conf config) GetNext() ([]string, error) {
+	if len(conf.input) == 0 {
+		return nil, errors.New("Illegal Configuration.")
+	}
+	return append([]string{conf.input[0]}, conf.states...), nil
+}
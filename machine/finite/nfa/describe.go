@@ -0,0 +1,48 @@
+package nfa
+
+import (
+	"github.com/cjcodell1/tint/machine"
+)
+
+func (n nfa) Type() string {
+	return machine.NFA
+}
+
+func (n nfa) States() []string {
+	return machine.States(n.start, n.Edges(), n.accepts...)
+}
+
+func (n nfa) StartState() string {
+	return n.start
+}
+
+func (n nfa) AcceptStates() []string {
+	return append([]string{}, n.accepts...)
+}
+
+// An NFA rejects in any state that is not an accept state, so there are no reject states.
+func (n nfa) RejectStates() []string {
+	return []string{}
+}
+
+// output: [[state, symbol, state], ...]
+func (n nfa) Transitions() [][]string {
+	trans := make([][]string, 0, len(n.trans))
+	for _, t := range n.trans {
+		trans = append(trans, append(t.GetInput(), t.GetOutput()...))
+	}
+	return trans
+}
+
+// output: [[from, to, symbol], ...], with epsilon transitions labelled "ε"
+func (n nfa) Edges() [][]string {
+	edges := make([][]string, 0, len(n.trans))
+	for _, t := range n.trans {
+		label := t.in.symbol
+		if label == machine.Epsilon {
+			label = "ε"
+		}
+		edges = append(edges, []string{t.in.state, t.out.state, label})
+	}
+	return edges
+}
@@ -0,0 +1,89 @@
+package nfa
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+)
+
+// Determinize builds a DFA that recognizes the same language as the given NFA, with the subset construction.
+// Each state of the DFA is the epsilon closure of a set of states of the NFA,
+// and only the sets reachable from the start state are built.
+// The empty set is the trap state, which is only built if it is reachable.
+//
+// The states are named q0, q1, ... in the order they are reached,
+// or, if subsetNames is true, after the sets they are, like {q0,q2}.
+//
+// Output: the DFA, and a map from each of its states to the states of the NFA it is the set of.
+func Determinize(m machine.Machine, subsetNames bool) (machine.Machine, map[string][]string, error) {
+	n, ok := m.(nfa)
+	if !ok {
+		return nil, nil, errors.New("Only an NFA can be determinized.")
+	}
+
+	alphabet := []string{}
+	seenSymbol := make(map[string]bool)
+	for _, trans := range n.trans {
+		if trans.in.symbol != machine.Epsilon && !seenSymbol[trans.in.symbol] {
+			seenSymbol[trans.in.symbol] = true
+			alphabet = append(alphabet, trans.in.symbol)
+		}
+	}
+	accept := make(map[string]bool)
+	for _, state := range n.accepts {
+		accept[state] = true
+	}
+
+	// the sets are keyed by their sorted states, which closure returns
+	names := make(map[string]string)
+	subsets := make(map[string][]string)
+	name := func(set []string) (string, bool) {
+		key := strings.Join(set, "\x00")
+		if name, ok := names[key]; ok {
+			return name, false
+		}
+		if subsetNames {
+			names[key] = "{" + strings.Join(set, ",") + "}"
+		} else {
+			names[key] = "q" + strconv.Itoa(len(names))
+		}
+		subsets[names[key]] = set
+		return names[key], true
+	}
+
+	start := n.closure([]string{n.start})
+	startName, _ := name(start)
+	trans := [][]string{}
+	accepts := []string{}
+	queue := [][]string{start}
+	for len(queue) > 0 {
+		set := queue[0]
+		queue = queue[1:]
+		from, _ := name(set)
+
+		for _, state := range set {
+			if accept[state] {
+				accepts = append(accepts, from)
+				break
+			}
+		}
+
+		for _, symbol := range alphabet {
+			next := n.closure(n.move(set, symbol))
+			to, added := name(next)
+			if added {
+				queue = append(queue, next)
+			}
+			trans = append(trans, []string{from, symbol, to})
+		}
+	}
+
+	d, err := dfa.MakeDFA(trans, startName, accepts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, subsets, nil
+}
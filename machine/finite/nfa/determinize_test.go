@@ -0,0 +1,58 @@
+package nfa_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+)
+
+type determinizeT struct {
+	n           machine.Machine
+	name        string
+	subsetNames bool
+	expect      string
+	subsets     string
+}
+
+var determinizeTests []determinizeT
+
+func TestDeterminize(t *testing.T) {
+	for _, tc := range determinizeTests {
+		got, subsets, err := nfa.Determinize(tc.n, tc.subsetNames)
+		if err != nil {
+			t.Errorf("Determinize(%s) == %s", tc.name, err)
+			continue
+		}
+		d := got.(machine.Describer)
+		actual := fmt.Sprintf("%s %v %v", d.StartState(), d.AcceptStates(), d.Transitions())
+		if actual != tc.expect {
+			t.Errorf("Determinize(%s, %t) == %s != %s", tc.name, tc.subsetNames, actual, tc.expect)
+		}
+		if fmt.Sprint(subsets) != tc.subsets {
+			t.Errorf("Determinize(%s, %t) subsets %v != %s", tc.name, tc.subsetNames, subsets, tc.subsets)
+		}
+	}
+}
+
+func TestDeterminizeNotNFA(t *testing.T) {
+	_, _, err := nfa.Determinize(nil, false)
+	if err == nil {
+		t.Error("Determinize(nil) did not error.")
+	}
+}
+
+func init() {
+	determinizeTests = []determinizeT{
+		{endsInAbNFA, "endsInAbNFA", false,
+			"q0 [q2] [[q0 a q1] [q0 b q0] [q1 a q1] [q1 b q2] [q2 a q1] [q2 b q0]]",
+			"map[q0:[q0] q1:[q0 q1] q2:[q0 q2]]"},
+		{endsInAbNFA, "endsInAbNFA", true,
+			"{q0} [{q0,q2}] [[{q0} a {q0,q1}] [{q0} b {q0}] [{q0,q1} a {q0,q1}] [{q0,q1} b {q0,q2}] [{q0,q2} a {q0,q1}] [{q0,q2} b {q0}]]",
+			"map[{q0,q1}:[q0 q1] {q0,q2}:[q0 q2] {q0}:[q0]]"},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", true,
+			"{as,bs,start} [{as,bs,start} {as} {bs}] [[{as,bs,start} a {as}] [{as,bs,start} b {bs}] [{as} a {as}] [{as} b {}] [{bs} a {}] [{bs} b {bs}] [{} a {}] [{} b {}]]",
+			"map[{as,bs,start}:[as bs start] {as}:[as] {bs}:[bs] {}:[]]"},
+	}
+}
@@ -0,0 +1,132 @@
+// Package nfa provides nondeterministic finite automata with epsilon transitions.
+package nfa
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+type nfa struct {
+	trans   []transition
+	start   string
+	accepts []string
+}
+
+// MakeNFA is the constructor for an NFA.
+// A transition with the symbol machine.Epsilon is taken without reading any input.
+func MakeNFA(trans [][]string, start string, accepts []string) (machine.Machine, error) {
+	transitions := []transition{}
+	for i, tran := range trans {
+		t, err := makeTransition(tran)
+		if err != nil {
+			return nil, machine.TransitionError{Index: i, Err: err}
+		}
+		transitions = append(transitions, t)
+	}
+
+	return nfa{transitions, start, accepts}, nil
+}
+
+// Start builds the first Configuration given a space-delimited input string.
+// The active states are the epsilon closure of the start state.
+func (n nfa) Start(input string) machine.Configuration {
+	return config{n.closure([]string{n.start}), strings.Fields(input)}
+}
+
+// Step reads one symbol on every active state at once.
+func (n nfa) Step(conf machine.Configuration) (machine.Configuration, error) {
+	important, err := conf.GetNext()
+	if err != nil {
+		return nil, err
+	}
+	if len(important) < 1 {
+		return nil, errors.New("Illegal Configuration")
+	}
+
+	// get the current symbol and states
+	symbol := important[0]
+	states := important[1:]
+
+	next_states := n.closure(n.move(states, symbol))
+
+	next_conf, err := conf.Next(next_states)
+	if err != nil {
+		return nil, err
+	}
+
+	return next_conf, nil
+}
+
+// IsAccept returns true if the input is used up and any active state is an accept state.
+func (n nfa) IsAccept(conf machine.Configuration) bool {
+	if !conf.CanNext() {
+		for _, state := range n.accepts {
+			if conf.IsState(state) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// IsReject returns true if the input is used up, or there are no active states,
+// and no active state is an accept state.
+func (n nfa) IsReject(conf machine.Configuration) bool {
+	if !conf.CanNext() {
+		for _, state := range n.accepts {
+			if conf.IsState(state) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// move returns every state reachable from the given states by reading the symbol.
+func (n nfa) move(states []string, symbol string) []string {
+	next := []string{}
+	for _, state := range states {
+		for _, trans := range n.trans {
+			if trans.in.state == state && trans.in.symbol == symbol {
+				next = append(next, trans.out.state)
+			}
+		}
+	}
+	return next
+}
+
+// closure returns the given states and every state reachable from them by epsilon transitions.
+// The result is sorted and without duplicates.
+func (n nfa) closure(states []string) []string {
+	seen := make(map[string]bool)
+	stack := []string{}
+	for _, state := range states {
+		if !seen[state] {
+			seen[state] = true
+			stack = append(stack, state)
+		}
+	}
+
+	for len(stack) != 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, trans := range n.trans {
+			if trans.in.state == state && trans.in.symbol == machine.Epsilon && !seen[trans.out.state] {
+				seen[trans.out.state] = true
+				stack = append(stack, trans.out.state)
+			}
+		}
+	}
+
+	closed := make([]string, 0, len(seen))
+	for state := range seen {
+		closed = append(closed, state)
+	}
+	sort.Strings(closed)
+	return closed
+}
@@ -0,0 +1,258 @@
+package nfa_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+)
+
+type makeNFAT struct {
+	trans   [][]string
+	start   string
+	accepts []string
+	err     error
+}
+
+type startT struct {
+	n      machine.Machine
+	name   string
+	input  string
+	expect string
+}
+
+type stepT struct {
+	n      machine.Machine
+	name   string
+	input  machine.Configuration
+	expect string
+	err    error
+}
+
+type isAcceptT struct {
+	n      machine.Machine
+	name   string
+	input  machine.Configuration
+	expect bool
+}
+
+type isRejectT struct {
+	n      machine.Machine
+	name   string
+	input  machine.Configuration
+	expect bool
+}
+
+var makeNFATests []makeNFAT
+var startTests []startT
+var stepTests []stepT
+var isAcceptTests []isAcceptT
+var isRejectTests []isRejectT
+
+func TestMakeNFA(t *testing.T) {
+	for _, tc := range makeNFATests {
+		got, err := nfa.MakeNFA(tc.trans, tc.start, tc.accepts)
+		_, ok := got.(machine.Machine)
+		if !ok {
+			t.Error("Did not create an NFA.")
+		}
+		if err != tc.err {
+			t.Errorf("Actual error %s != expected error %s", err, tc.err)
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	for _, tc := range startTests {
+		got := fmt.Sprint(tc.n.Start(tc.input))
+		if got != tc.expect {
+			t.Errorf("%s.Start(%s) == %s != %s", tc.name, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	for _, tc := range stepTests {
+		ans, err := tc.n.Step(tc.input)
+		got := fmt.Sprint(ans)
+		if got != tc.expect {
+			t.Errorf("%s.Step(%s) == %s != %s", tc.name, tc.input, got, tc.expect)
+		}
+		if err != tc.err {
+			t.Errorf("%s.Step(%s) errored with %s != %s", tc.name, tc.input, err, tc.err)
+		}
+	}
+}
+
+func TestIsAccept(t *testing.T) {
+	for _, tc := range isAcceptTests {
+		got := tc.n.IsAccept(tc.input)
+		if got != tc.expect {
+			t.Errorf("%s.IsAccept(%s) == %t != %t", tc.name, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestIsReject(t *testing.T) {
+	for _, tc := range isRejectTests {
+		got := tc.n.IsReject(tc.input)
+		if got != tc.expect {
+			t.Errorf("%s.IsReject(%s) == %t != %t", tc.name, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	conf := endsInAbNFA.Start("a b")
+	expect := "{q0}: a b"
+	if got := conf.Print(); got != expect {
+		t.Errorf("endsInAbNFA.Start(a b).Print() == %q != %q", got, expect)
+	}
+
+	conf, _ = endsInAbNFA.Step(conf)
+	expect = "{q0, q1}: b"
+	if got := conf.Print(); got != expect {
+		t.Errorf("endsInAbNFA.Step(...).Print() == %q != %q", got, expect)
+	}
+}
+
+// recognizes strings over {a, b} ending in "a b"
+var endsInAbNFA, _ = nfa.MakeNFA(
+	[][]string{
+		{"q0", "a", "q0"},
+		{"q0", "b", "q0"},
+		{"q0", "a", "q1"},
+
+		{"q1", "b", "q2"},
+	},
+	"q0",
+	[]string{"q2"})
+
+// recognizes a* or b* with an epsilon transition to each branch
+var aStarOrBStarNFA, _ = nfa.MakeNFA(
+	[][]string{
+		{"start", machine.Epsilon, "as"},
+		{"start", machine.Epsilon, "bs"},
+
+		{"as", "a", "as"},
+
+		{"bs", "b", "bs"},
+	},
+	"start",
+	[]string{"as", "bs"})
+
+// follows a chain of epsilon transitions after each symbol
+var epsilonChainNFA, _ = nfa.MakeNFA(
+	[][]string{
+		{"q0", "a", "q1"},
+		{"q1", machine.Epsilon, "q2"},
+		{"q2", machine.Epsilon, "q3"},
+		{"q3", machine.Epsilon, "q1"},
+		{"q3", "a", "q1"},
+	},
+	"q0",
+	[]string{"q3"})
+
+// set up the makeNFATests automatically
+func init() {
+	makeNFATests = []makeNFAT{
+		{[][]string{{"start", "a", "start"}}, "start", []string{}, nil},
+		{[][]string{{"start", machine.Epsilon, "start"}}, "start", []string{"start"}, nil},
+	}
+}
+
+// set up the startTests automatically
+func init() {
+	startTests = []startT{
+		{endsInAbNFA, "endsInAbNFA", "a b", "{[q0] [a b]}"},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", "", "{[as bs start] []}"},
+		{epsilonChainNFA, "epsilonChainNFA", "a", "{[q0] [a]}"},
+	}
+}
+
+// set up the stepTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+	var step2 machine.Configuration
+
+	start = endsInAbNFA.Start("b a b")
+	step1, _ = endsInAbNFA.Step(start)
+	step2, _ = endsInAbNFA.Step(step1)
+	stepTests = append(stepTests, []stepT{
+		{endsInAbNFA, "endsInAbNFA", start, "{[q0] [a b]}", nil},
+		{endsInAbNFA, "endsInAbNFA", step1, "{[q0 q1] [b]}", nil},
+		{endsInAbNFA, "endsInAbNFA", step2, "{[q0 q2] []}", nil},
+	}...)
+
+	start = aStarOrBStarNFA.Start("a a b")
+	step1, _ = aStarOrBStarNFA.Step(start)
+	step2, _ = aStarOrBStarNFA.Step(step1)
+	stepTests = append(stepTests, []stepT{
+		{aStarOrBStarNFA, "aStarOrBStarNFA", start, "{[as] [a b]}", nil},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", step1, "{[as] [b]}", nil},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", step2, "{[] []}", nil},
+	}...)
+
+	start = epsilonChainNFA.Start("a a")
+	step1, _ = epsilonChainNFA.Step(start)
+	stepTests = append(stepTests, []stepT{
+		{epsilonChainNFA, "epsilonChainNFA", start, "{[q1 q2 q3] [a]}", nil},
+		{epsilonChainNFA, "epsilonChainNFA", step1, "{[q1 q2 q3] []}", nil},
+	}...)
+}
+
+// set up the isAcceptTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+	var step2 machine.Configuration
+
+	start = endsInAbNFA.Start("a b")
+	step1, _ = endsInAbNFA.Step(start)
+	step2, _ = endsInAbNFA.Step(step1)
+	isAcceptTests = append(isAcceptTests, []isAcceptT{
+		{endsInAbNFA, "endsInAbNFA", start, false},
+		{endsInAbNFA, "endsInAbNFA", step1, false},
+		{endsInAbNFA, "endsInAbNFA", step2, true},
+	}...)
+
+	start = aStarOrBStarNFA.Start("")
+	isAcceptTests = append(isAcceptTests, []isAcceptT{
+		{aStarOrBStarNFA, "aStarOrBStarNFA", start, true},
+	}...)
+
+	start = aStarOrBStarNFA.Start("a b")
+	step1, _ = aStarOrBStarNFA.Step(start)
+	isAcceptTests = append(isAcceptTests, []isAcceptT{
+		{aStarOrBStarNFA, "aStarOrBStarNFA", start, false},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", step1, false},
+	}...)
+}
+
+// set up the isRejectTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+	var step2 machine.Configuration
+
+	start = endsInAbNFA.Start("b a")
+	step1, _ = endsInAbNFA.Step(start)
+	step2, _ = endsInAbNFA.Step(step1)
+	isRejectTests = append(isRejectTests, []isRejectT{
+		{endsInAbNFA, "endsInAbNFA", start, false},
+		{endsInAbNFA, "endsInAbNFA", step1, false},
+		{endsInAbNFA, "endsInAbNFA", step2, true},
+	}...)
+
+	// rejects as soon as there are no active states left
+	start = aStarOrBStarNFA.Start("a b a")
+	step1, _ = aStarOrBStarNFA.Step(start)
+	step2, _ = aStarOrBStarNFA.Step(step1)
+	isRejectTests = append(isRejectTests, []isRejectT{
+		{aStarOrBStarNFA, "aStarOrBStarNFA", start, false},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", step1, false},
+		{aStarOrBStarNFA, "aStarOrBStarNFA", step2, true},
+	}...)
+}
@@ -0,0 +1,111 @@
+package regex
+
+import (
+	"fmt"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// FromAutomaton builds a regular expression for the language of a DFA or NFA, with state elimination.
+// A new start state and a new accept state are added, then every other state is eliminated,
+// replacing the paths through it with regular expressions, until only the new states are left.
+// The state with the fewest paths through it is eliminated first, which keeps the expression short,
+// and the expression is simplified as it is built.
+//
+// Like Step, only the first DFA transition for a state and symbol is used,
+// and states that cannot be reached from the start state are left out.
+func FromAutomaton(d machine.Describer) (string, error) {
+	if d.Type() != machine.DFA && d.Type() != machine.NFA {
+		return "", fmt.Errorf("A regular expression cannot be built from a %s.", d.Type())
+	}
+
+	// the reachable states, by index, after the new start and accept states
+	const start, accept = 0, 1
+	index := map[string]int{d.StartState(): 2}
+	states := []string{d.StartState()}
+	out := make(map[string][][]string)
+	for _, t := range d.Transitions() {
+		out[t[0]] = append(out[t[0]], t)
+	}
+	for i := 0; i < len(states); i++ {
+		for _, t := range out[states[i]] {
+			if _, ok := index[t[2]]; !ok {
+				index[t[2]] = len(states) + 2
+				states = append(states, t[2])
+			}
+		}
+	}
+	n := len(states) + 2
+
+	// paths[i][j] is the regular expression for going from state i to state j
+	paths := make([][]node, n)
+	for i := range paths {
+		paths[i] = make([]node, n)
+		for j := range paths[i] {
+			paths[i][j] = none()
+		}
+	}
+	paths[start][index[d.StartState()]] = empty()
+	for _, state := range d.AcceptStates() {
+		if i, ok := index[state]; ok {
+			paths[i][accept] = empty()
+		}
+	}
+	used := make(map[[2]string]bool)
+	for _, state := range states {
+		for _, t := range out[state] {
+			read := empty()
+			if t[1] != machine.Epsilon {
+				read = symbol(t[1])
+			}
+			if d.Type() == machine.DFA {
+				if used[[2]string{t[0], t[1]}] {
+					continue
+				}
+				used[[2]string{t[0], t[1]}] = true
+			}
+			i, j := index[t[0]], index[t[2]]
+			paths[i][j] = union(paths[i][j], read)
+		}
+	}
+
+	eliminated := make([]bool, n)
+	for left := len(states); left > 0; left-- {
+		// find the state with the fewest paths through it
+		k, fewest := -1, 0
+		for s := 2; s < n; s++ {
+			if eliminated[s] {
+				continue
+			}
+			in, out := 0, 0
+			for i := 0; i < n; i++ {
+				if i != s && !eliminated[i] && !isNone(paths[i][s]) {
+					in++
+				}
+				if i != s && !eliminated[i] && !isNone(paths[s][i]) {
+					out++
+				}
+			}
+			if k == -1 || in*out < fewest {
+				k, fewest = s, in*out
+			}
+		}
+
+		// replace every path through it
+		loop := star(paths[k][k])
+		for i := 0; i < n; i++ {
+			if i == k || eliminated[i] || isNone(paths[i][k]) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if j == k || eliminated[j] || isNone(paths[k][j]) {
+					continue
+				}
+				paths[i][j] = union(paths[i][j], concat(paths[i][k], loop, paths[k][j]))
+			}
+		}
+		eliminated[k] = true
+	}
+
+	return paths[start][accept].String(), nil
+}
@@ -0,0 +1,131 @@
+package regex_test
+
+import (
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+	"github.com/cjcodell1/tint/machine/finite/regex"
+)
+
+type fromAutomatonT struct {
+	m      machine.Machine
+	name   string
+	expect string
+}
+
+var fromAutomatonTests []fromAutomatonT
+
+// TestFromAutomaton checks the regular expression, and that it recognizes the same language as the machine.
+func TestFromAutomaton(t *testing.T) {
+	for _, tc := range fromAutomatonTests {
+		got, err := regex.FromAutomaton(tc.m.(machine.Describer))
+		if err != nil {
+			t.Errorf("FromAutomaton(%s) == %s", tc.name, err)
+			continue
+		}
+		if got != tc.expect {
+			t.Errorf("FromAutomaton(%s) == %q != %q", tc.name, got, tc.expect)
+		}
+
+		compiled, err := regex.CompileDFA(got)
+		if err != nil {
+			t.Errorf("CompileDFA(FromAutomaton(%s)) == %s", tc.name, err)
+			continue
+		}
+		original := tc.m
+		if original.(machine.Describer).Type() == machine.NFA {
+			original, _, _ = nfa.Determinize(original, false)
+		}
+		counter, err := dfa.Equivalent(original, compiled)
+		if err != nil || counter != nil {
+			t.Errorf("Equivalent(%s, CompileDFA(%q)) == %v, %v != nil, nil", tc.name, got, counter, err)
+		}
+	}
+}
+
+func TestFromAutomatonPDA(t *testing.T) {
+	m, _ := regex.Compile("a")
+	d := m.(machine.Describer)
+	if _, err := regex.FromAutomaton(pdaDescriber{d}); err == nil {
+		t.Error("FromAutomaton(PDA) did not error.")
+	}
+}
+
+// pdaDescriber pretends to be a PDA.
+type pdaDescriber struct {
+	machine.Describer
+}
+
+func (p pdaDescriber) Type() string {
+	return machine.PDA
+}
+
+var evenAsDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"even", "a", "odd"},
+		{"even", "b", "even"},
+		{"odd", "a", "even"},
+		{"odd", "b", "odd"},
+	},
+	"even",
+	[]string{"even"})
+
+var endsInAbDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "q1"},
+		{"q0", "b", "q0"},
+		{"q1", "a", "q1"},
+		{"q1", "b", "q2"},
+		{"q2", "a", "q1"},
+		{"q2", "b", "q0"},
+	},
+	"q0",
+	[]string{"q2"})
+
+var noneDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "q0"},
+	},
+	"q0",
+	[]string{})
+
+var onlyEmptyDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "dead"},
+		{"dead", "a", "dead"},
+	},
+	"q0",
+	[]string{"q0"})
+
+// the first transition for q0 and a is the one followed
+var shadowedDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"q0", "a", "q1"},
+		{"q0", "a", "q0"},
+		{"q1", "a", "q1"},
+		{"unreachable", "b", "q0"},
+	},
+	"q0",
+	[]string{"q1"})
+
+var oneOrTwoNFA, _ = nfa.MakeNFA(
+	[][]string{
+		{"s", "one", "a"},
+		{"s", "", "b"},
+		{"b", "two", "a"},
+	},
+	"s",
+	[]string{"a"})
+
+func init() {
+	fromAutomatonTests = []fromAutomatonT{
+		{evenAsDFA, "evenAsDFA", "(b | a b* a)*"},
+		{endsInAbDFA, "endsInAbDFA", "b* a+ b ((a | b+ a) a* b)*"},
+		{noneDFA, "noneDFA", "[]"},
+		{onlyEmptyDFA, "onlyEmptyDFA", "()"},
+		{shadowedDFA, "shadowedDFA", "a+"},
+		{oneOrTwoNFA, "oneOrTwoNFA", "[one two]"},
+	}
+}
@@ -0,0 +1,240 @@
+package regex
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// The operators of a regular expression.
+const (
+	Union    string = "|"
+	Star     string = "*"
+	Plus     string = "+"
+	Optional string = "?"
+	Open     string = "("
+	Close    string = ")"
+	OpenSet  string = "["
+	CloseSet string = "]"
+	Escape   string = "\\"
+)
+
+const operators = Union + Star + Plus + Optional + Open + Close + OpenSet + CloseSet
+
+// the kinds of nodes in a parsed regular expression
+type kind int
+
+const (
+	symbolNode   kind = iota // reads the symbol
+	setNode                  // reads any one of the symbols
+	emptyNode                // reads nothing
+	concatNode               // reads each child in order
+	unionNode                // reads any one child
+	starNode                 // reads the child zero or more times
+	plusNode                 // reads the child one or more times
+	optionalNode             // reads the child zero or one times
+)
+
+type node struct {
+	kind     kind
+	symbols  []string // for a symbolNode or setNode
+	children []node
+}
+
+// token is a symbol, or an operator if op is true.
+type token struct {
+	text string
+	op   bool
+}
+
+// tokenize splits a regular expression into symbols and operators.
+// Symbols are separated by whitespace or operators, and an operator is escaped with a backslash.
+func tokenize(expr string) ([]token, error) {
+	tokens := []token{}
+	var symbol strings.Builder
+	inSymbol := false
+	end := func() {
+		if inSymbol {
+			tokens = append(tokens, token{symbol.String(), false})
+			symbol.Reset()
+			inSymbol = false
+		}
+	}
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case string(r) == Escape:
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("The regular expression ends with %s, which escapes nothing.", Escape)
+			}
+			i++
+			symbol.WriteRune(runes[i])
+			inSymbol = true
+		case unicode.IsSpace(r):
+			end()
+		case strings.ContainsRune(operators, r):
+			end()
+			tokens = append(tokens, token{string(r), true})
+		default:
+			symbol.WriteRune(r)
+			inSymbol = true
+		}
+	}
+	end()
+
+	return tokens, nil
+}
+
+// parser is a recursive descent parser, where union binds loosest, then concatenation,
+// then the postfix operators.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses a regular expression.
+func parse(expr string) (node, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return node{}, err
+	}
+
+	p := parser{tokens, 0}
+	n, err := p.union()
+	if err != nil {
+		return node{}, err
+	}
+	if p.pos != len(p.tokens) {
+		return node{}, fmt.Errorf("There is a %s without a matching %s.", p.tokens[p.pos].text, Open)
+	}
+	return n, nil
+}
+
+// peek returns the next token, or false if there are none.
+func (p *parser) peek() (token, bool) {
+	if p.pos == len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// union: concat ("|" concat)*
+func (p *parser) union() (node, error) {
+	children := []node{}
+	for {
+		n, err := p.concat()
+		if err != nil {
+			return node{}, err
+		}
+		children = append(children, n)
+
+		t, ok := p.peek()
+		if !ok || t != (token{Union, true}) {
+			break
+		}
+		p.pos++
+	}
+
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return node{kind: unionNode, children: children}, nil
+}
+
+// concat: postfix*, where nothing is the empty string
+func (p *parser) concat() (node, error) {
+	children := []node{}
+	for {
+		t, ok := p.peek()
+		if !ok || t == (token{Union, true}) || t == (token{Close, true}) {
+			break
+		}
+		n, err := p.postfix()
+		if err != nil {
+			return node{}, err
+		}
+		children = append(children, n)
+	}
+
+	switch len(children) {
+	case 0:
+		return node{kind: emptyNode}, nil
+	case 1:
+		return children[0], nil
+	default:
+		return node{kind: concatNode, children: children}, nil
+	}
+}
+
+// postfix: atom ("*" | "+" | "?")*
+func (p *parser) postfix() (node, error) {
+	n, err := p.atom()
+	if err != nil {
+		return node{}, err
+	}
+
+	for {
+		t, ok := p.peek()
+		if !ok || !t.op {
+			return n, nil
+		}
+		switch t.text {
+		case Star:
+			n = node{kind: starNode, children: []node{n}}
+		case Plus:
+			n = node{kind: plusNode, children: []node{n}}
+		case Optional:
+			n = node{kind: optionalNode, children: []node{n}}
+		default:
+			return n, nil
+		}
+		p.pos++
+	}
+}
+
+// atom: symbol | "(" union ")" | "[" symbol* "]"
+func (p *parser) atom() (node, error) {
+	t, _ := p.peek()
+	p.pos++
+	if !t.op {
+		return node{kind: symbolNode, symbols: []string{t.text}}, nil
+	}
+
+	switch t.text {
+	case Open:
+		n, err := p.union()
+		if err != nil {
+			return node{}, err
+		}
+		if t, ok := p.peek(); !ok || t != (token{Close, true}) {
+			return node{}, fmt.Errorf("There is a %s without a matching %s.", Open, Close)
+		}
+		p.pos++
+		return n, nil
+
+	case OpenSet:
+		symbols := []string{}
+		for {
+			t, ok := p.peek()
+			if !ok {
+				return node{}, fmt.Errorf("There is a %s without a matching %s.", OpenSet, CloseSet)
+			}
+			p.pos++
+			if t == (token{CloseSet, true}) {
+				return node{kind: setNode, symbols: symbols}, nil
+			}
+			if t.op {
+				return node{}, fmt.Errorf("%s cannot be inside of %s%s, escape it with %s.", t.text, OpenSet, CloseSet, Escape)
+			}
+			symbols = append(symbols, t.text)
+		}
+
+	case CloseSet:
+		return node{}, fmt.Errorf("There is a %s without a matching %s.", CloseSet, OpenSet)
+
+	default:
+		return node{}, fmt.Errorf("%s must come after a symbol, %s, or %s.", t.text, Close, CloseSet)
+	}
+}
@@ -0,0 +1,126 @@
+// Package regex provides regular expressions over space-delimited symbols,
+// which are compiled to NFAs with Thompson's construction.
+//
+// A symbol is any run of characters other than whitespace and the operators | * + ? ( ) [ ],
+// so "a b" is two symbols but "ab" is one. An operator is read as part of a symbol when escaped with \.
+// Expressions are concatenated by writing them one after another, and unioned with |.
+// After an expression, * repeats it zero or more times, + one or more times, and ? zero or one times.
+// Parentheses group an expression, and [a b c] is any one of the symbols a, b, or c.
+// Nothing, like "" or the right side of "a |", is the empty string, and [] is no string at all.
+package regex
+
+import (
+	"strconv"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+)
+
+// Compile compiles a regular expression to an NFA with Thompson's construction.
+// The states are named q0, q1, ..., and the NFA has one accept state.
+func Compile(expr string) (machine.Machine, error) {
+	n, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var c compiler
+	start, accept := c.compile(n)
+	return nfa.MakeNFA(c.trans, start, []string{accept})
+}
+
+// compiler builds the transitions of the NFA.
+type compiler struct {
+	trans  [][]string
+	states int
+}
+
+// state names a new state.
+func (c *compiler) state() string {
+	name := "q" + strconv.Itoa(c.states)
+	c.states++
+	return name
+}
+
+func (c *compiler) add(from string, symbol string, to string) {
+	c.trans = append(c.trans, []string{from, symbol, to})
+}
+
+// compile builds the NFA fragment of a node, which only enters at its start state and only leaves from its accept state.
+// Output: the start state and the accept state
+func (c *compiler) compile(n node) (string, string) {
+	switch n.kind {
+	case symbolNode, setNode:
+		start, accept := c.state(), c.state()
+		for _, symbol := range n.symbols {
+			c.add(start, symbol, accept)
+		}
+		return start, accept
+
+	case concatNode:
+		start, accept := c.compile(n.children[0])
+		for _, child := range n.children[1:] {
+			s, a := c.compile(child)
+			c.add(accept, machine.Epsilon, s)
+			accept = a
+		}
+		return start, accept
+
+	case unionNode:
+		start := c.state()
+		ends := []string{}
+		for _, child := range n.children {
+			s, a := c.compile(child)
+			c.add(start, machine.Epsilon, s)
+			ends = append(ends, a)
+		}
+		accept := c.state()
+		for _, a := range ends {
+			c.add(a, machine.Epsilon, accept)
+		}
+		return start, accept
+
+	case starNode, plusNode, optionalNode:
+		start := c.state()
+		s, a := c.compile(n.children[0])
+		accept := c.state()
+		c.add(start, machine.Epsilon, s)
+		c.add(a, machine.Epsilon, accept)
+		if n.kind != plusNode {
+			c.add(start, machine.Epsilon, accept)
+		}
+		if n.kind != optionalNode {
+			c.add(a, machine.Epsilon, s)
+		}
+		return start, accept
+
+	default: // emptyNode
+		start, accept := c.state(), c.state()
+		c.add(start, machine.Epsilon, accept)
+		return start, accept
+	}
+}
+
+// CompileDFA compiles a regular expression to the minimal DFA that recognizes it,
+// by determinizing and minimizing the NFA that Compile builds.
+func CompileDFA(expr string) (machine.Machine, error) {
+	n, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return MinimalDFA(n)
+}
+
+// MinimalDFA determinizes and minimizes an NFA that Compile built, e.g. from a regex file.
+func MinimalDFA(n machine.Machine) (machine.Machine, error) {
+	d, _, err := nfa.Determinize(n, false)
+	if err != nil {
+		return nil, err
+	}
+	min, _, err := dfa.Minimize(d)
+	if err != nil {
+		return nil, err
+	}
+	return min, nil
+}
@@ -0,0 +1,114 @@
+package regex_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/regex"
+)
+
+type compileT struct {
+	expr    string
+	accepts []string
+	rejects []string
+}
+
+type errorT struct {
+	expr   string
+	expect string
+}
+
+var compileTests []compileT
+var errorTests []errorT
+
+// run simulates a machine until it halts.
+func run(m machine.Machine, input string) (bool, error) {
+	conf := m.Start(input)
+	for !m.IsAccept(conf) && !m.IsReject(conf) {
+		var err error
+		conf, err = m.Step(conf)
+		if err != nil {
+			return false, err
+		}
+	}
+	return m.IsAccept(conf), nil
+}
+
+func TestCompile(t *testing.T) {
+	for _, tc := range compileTests {
+		for name, compile := range map[string]func(string) (machine.Machine, error){
+			"Compile":    regex.Compile,
+			"CompileDFA": regex.CompileDFA,
+		} {
+			m, err := compile(tc.expr)
+			if err != nil {
+				t.Errorf("%s(%q) == %s", name, tc.expr, err)
+				continue
+			}
+			for _, input := range tc.accepts {
+				if got, err := run(m, input); !got || err != nil {
+					t.Errorf("%s(%q) on %q == %t, %v != true, nil", name, tc.expr, input, got, err)
+				}
+			}
+			// a DFA has no transitions for symbols that are not in the regular expression, so it errors instead
+			for _, input := range tc.rejects {
+				if got, err := run(m, input); got || (err != nil && name == "Compile") {
+					t.Errorf("%s(%q) on %q == %t, %v != false, nil", name, tc.expr, input, got, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, tc := range errorTests {
+		_, err := regex.Compile(tc.expr)
+		if fmt.Sprint(err) != tc.expect {
+			t.Errorf("Compile(%q) errored with %v != %s", tc.expr, err, tc.expect)
+		}
+	}
+}
+
+func TestCompileDFA(t *testing.T) {
+	m, _ := regex.CompileDFA("[a b]* a b")
+	d := m.(machine.Describer)
+	if d.Type() != machine.DFA || len(d.States()) != 3 {
+		t.Errorf("CompileDFA([a b]* a b) == %s with states %s, not the minimal DFA", d.Type(), strings.Join(d.States(), " "))
+	}
+}
+
+func init() {
+	compileTests = []compileT{
+		{"a", []string{"a"}, []string{"", "b", "a a"}},
+		{"one two", []string{"one two"}, []string{"", "one", "onetwo", "two one"}},
+		{"onetwo", []string{"onetwo"}, []string{"one two"}},
+		{"a | b c", []string{"a", "b c"}, []string{"", "b", "a b c"}},
+		{"(a | b) c", []string{"a c", "b c"}, []string{"a", "c", "a b c"}},
+		{"a*", []string{"", "a", "a a a"}, []string{"b", "a b"}},
+		{"a+", []string{"a", "a a a"}, []string{"", "b"}},
+		{"a? b", []string{"b", "a b"}, []string{"", "a a b"}},
+		{"(a b)*", []string{"", "a b", "a b a b"}, []string{"a", "a b a"}},
+		{"(a*)*", []string{"", "a a"}, []string{"b"}},
+		{"[a b]* a b", []string{"a b", "b a a b"}, []string{"", "a", "b a"}},
+		{"[x y z]+", []string{"x", "z y x"}, []string{"", "a"}},
+		{"", []string{""}, []string{"a"}},
+		{"a | ", []string{"", "a"}, []string{"a a"}},
+		{"()", []string{""}, []string{"a"}},
+		{"[]", []string{}, []string{"", "a"}},
+		{`\* \| \[`, []string{"* | ["}, []string{"", "*"}},
+		{`a\ b`, []string{}, []string{"a b", "a"}}, // the escaped space makes "a b" one symbol, which no input can have
+	}
+
+	errorTests = []errorT{
+		{"(a", "There is a ( without a matching )."},
+		{"a)", "There is a ) without a matching (."},
+		{"[a", "There is a [ without a matching ]."},
+		{"a]", "There is a ] without a matching [."},
+		{"* a", "* must come after a symbol, ), or ]."},
+		{"a | + b", "+ must come after a symbol, ), or ]."},
+		{"[a (b)]", "( cannot be inside of [], escape it with \\."},
+		{`a \`, "The regular expression ends with \\, which escapes nothing."},
+	}
+}
@@ -0,0 +1,226 @@
+package regex
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The constructors below build nodes with basic algebraic simplification,
+// where the empty set is a setNode without symbols and the empty string is an emptyNode.
+
+// none is the empty set, which no string matches.
+func none() node {
+	return node{kind: setNode}
+}
+
+func isNone(n node) bool {
+	return n.kind == setNode && len(n.symbols) == 0
+}
+
+func symbol(s string) node {
+	return node{kind: symbolNode, symbols: []string{s}}
+}
+
+func empty() node {
+	return node{kind: emptyNode}
+}
+
+// union simplifies with
+//
+//	A | [] = A, A | A = A, a | b = [a b], () | A* = A*, () | A+ = A*, and () | A = A?
+func union(ns ...node) node {
+	alts := []node{}
+	symbols := []string{}
+	seen := make(map[string]bool)
+	seenSymbol := make(map[string]bool)
+	hasEmpty := false
+
+	var add func(n node)
+	add = func(n node) {
+		switch n.kind {
+		case symbolNode, setNode:
+			for _, s := range n.symbols {
+				if !seenSymbol[s] {
+					seenSymbol[s] = true
+					symbols = append(symbols, s)
+				}
+			}
+		case emptyNode:
+			hasEmpty = true
+		case optionalNode:
+			hasEmpty = true
+			add(n.children[0])
+		case unionNode:
+			for _, child := range n.children {
+				add(child)
+			}
+		default:
+			if key := n.String(); !seen[key] {
+				seen[key] = true
+				alts = append(alts, n)
+			}
+		}
+	}
+	for _, n := range ns {
+		add(n)
+	}
+
+	switch len(symbols) {
+	case 0:
+	case 1:
+		alts = append([]node{symbol(symbols[0])}, alts...)
+	default:
+		alts = append([]node{{kind: setNode, symbols: symbols}}, alts...)
+	}
+
+	var n node
+	switch len(alts) {
+	case 0:
+		if hasEmpty {
+			return empty()
+		}
+		return none()
+	case 1:
+		n = alts[0]
+	default:
+		n = node{kind: unionNode, children: alts}
+	}
+
+	if hasEmpty {
+		switch n.kind {
+		case starNode:
+			return n
+		case plusNode:
+			return star(n.children[0])
+		default:
+			return node{kind: optionalNode, children: []node{n}}
+		}
+	}
+	return n
+}
+
+// concat simplifies with
+//
+//	A [] = [], A () = A, A A* = A+, A* A = A+, and A* A* = A*
+func concat(ns ...node) node {
+	children := []node{}
+
+	var add func(n node)
+	add = func(n node) {
+		switch n.kind {
+		case emptyNode:
+			return
+		case concatNode:
+			for _, child := range n.children {
+				add(child)
+			}
+			return
+		}
+
+		if len(children) > 0 {
+			last := children[len(children)-1]
+			switch {
+			case last.kind == starNode && last.String() == n.String():
+				return
+			case n.kind == starNode && n.children[0].String() == last.String():
+				children[len(children)-1] = node{kind: plusNode, children: []node{last}}
+				return
+			case last.kind == starNode && last.children[0].String() == n.String():
+				children[len(children)-1] = node{kind: plusNode, children: []node{n}}
+				return
+			}
+		}
+		children = append(children, n)
+	}
+	for _, n := range ns {
+		if isNone(n) {
+			return none()
+		}
+		add(n)
+	}
+
+	switch len(children) {
+	case 0:
+		return empty()
+	case 1:
+		return children[0]
+	default:
+		return node{kind: concatNode, children: children}
+	}
+}
+
+// star simplifies with
+//
+//	[]* = (), ()* = (), A** = A*, A+* = A*, and A?* = A*
+func star(n node) node {
+	switch {
+	case isNone(n), n.kind == emptyNode:
+		return empty()
+	case n.kind == starNode, n.kind == plusNode, n.kind == optionalNode:
+		return star(n.children[0])
+	default:
+		return node{kind: starNode, children: []node{n}}
+	}
+}
+
+// String writes the node as a regular expression, with as few parentheses as it needs.
+func (n node) String() string {
+	switch n.kind {
+	case symbolNode:
+		return escape(n.symbols[0])
+
+	case setNode:
+		escaped := make([]string, len(n.symbols))
+		for i, s := range n.symbols {
+			escaped[i] = escape(s)
+		}
+		return OpenSet + strings.Join(escaped, " ") + CloseSet
+
+	case emptyNode:
+		return Open + Close
+
+	case concatNode:
+		parts := make([]string, len(n.children))
+		for i, child := range n.children {
+			parts[i] = child.String()
+			if child.kind == unionNode {
+				parts[i] = Open + parts[i] + Close
+			}
+		}
+		return strings.Join(parts, " ")
+
+	case unionNode:
+		parts := make([]string, len(n.children))
+		for i, child := range n.children {
+			parts[i] = child.String()
+		}
+		return strings.Join(parts, " "+Union+" ")
+
+	default:
+		child := n.children[0].String()
+		switch n.children[0].kind {
+		case concatNode, unionNode, starNode, plusNode, optionalNode:
+			child = Open + child + Close
+		}
+		switch n.kind {
+		case starNode:
+			return child + Star
+		case plusNode:
+			return child + Plus
+		default:
+			return child + Optional
+		}
+	}
+}
+
+// escape escapes the operators, backslashes, and whitespace in a symbol.
+func escape(s string) string {
+	var out strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(operators+Escape, r) || unicode.IsSpace(r) {
+			out.WriteString(Escape)
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
@@ -3,13 +3,19 @@ package machine
 
 // represents the available types of machines
 const (
-	DFA = "dfa"
-	ONE_WAY_TM  = "one-way-tm"
-	TWO_WAY_TM  = "two-way-tm"
+	DFA           = "dfa"
+	NFA           = "nfa"
+	PDA           = "pda"
+	ONE_WAY_TM    = "one-way-tm"
+	TWO_WAY_TM    = "two-way-tm"
+	MULTI_TAPE_TM = "multi-tape-tm"
+	NTM           = "nondeterministic-tm"
+	REGEX         = "regex"
 )
 
 const (
 	Wildcard string = "*"
+	Epsilon  string = "" // the empty string, used for transitions that do not read a symbol
 )
 
 // interface for all Machines (e.g. DFA, NFA, PDA, various TMs, etc.)
@@ -19,3 +25,49 @@ type Machine interface {
 	IsAccept(conf Configuration) bool
 	IsReject(conf Configuration) bool
 }
+
+// interface for nondeterministic Machines that can show how they accepted
+type Nondeterministic interface {
+	Machine
+	// Returns the Configurations from the start to an accepting branch of conf, or nil if none accept.
+	AcceptingPath(conf Configuration) []Configuration
+}
+
+// interface for Machines that can take a step by writing to a Configuration instead of copying it,
+// which is much faster when the Configuration is large
+type InPlaceStepper interface {
+	Machine
+	// Same as Step, but may change conf, so conf must not be used after.
+	StepInPlace(conf Configuration) (Configuration, error)
+	// Returns a copy of conf that stepping conf in place does not change.
+	Snapshot(conf Configuration) Configuration
+}
+
+// interface for Machines that can describe how they were made,
+// so they can be drawn, exported, or analyzed
+type Describer interface {
+	Machine
+	// Returns the type of the Machine, e.g. DFA or ONE_WAY_TM.
+	Type() string
+	// Returns every state, in the order they are first mentioned.
+	States() []string
+	// Returns the start state.
+	StartState() string
+	// Returns the accept states.
+	AcceptStates() []string
+	// Returns the reject states, if the Machine has any.
+	RejectStates() []string
+	// Returns the transitions, in the same form the Machine was made with.
+	Transitions() [][]string
+	// Returns the transitions as the edges of a state diagram: [from, to, label].
+	Edges() [][]string
+}
+
+// interface for pushdown Machines that can also describe their stack
+type StackDescriber interface {
+	Describer
+	// Returns the symbols on the stack at the start, space-delimited with the top first.
+	StartStack() string
+	// Returns true if the Machine also accepts by empty stack.
+	AcceptsEmptyStack() bool
+}
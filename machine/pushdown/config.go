@@ -0,0 +1,163 @@
+package pushdown
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// branch is one of the ways the PDA could have run so far.
+type branch struct {
+	state string
+	input []string // the rest of the input
+	stack []string // the top of the stack is the last symbol
+}
+
+// configuration holds every branch of the PDA that has not died yet, at the same depth of the breadth-first search.
+type configuration struct {
+	branches []branch
+	depth    int
+	seen     map[string]int // the depth each branch was first found at, shared by the whole search
+}
+
+// Print writes each branch on its own line as the state, the rest of the input, and the stack.
+// The stack is written top first.
+func (conf configuration) Print() string {
+	lines := make([]string, 0, len(conf.branches))
+	for _, b := range conf.branches {
+		var line strings.Builder
+
+		// the WriteString method on a strings.Builder always returns a nil error.
+		line.WriteString(b.state)
+		line.WriteString(":")
+		if len(b.input) != 0 {
+			line.WriteString(" ")
+			line.WriteString(strings.Join(b.input, " "))
+		}
+		line.WriteString(" |")
+		for i := len(b.stack) - 1; i >= 0; i-- {
+			line.WriteString(" ")
+			line.WriteString(b.stack[i])
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsState checks if any branch is in the given state.
+func (conf configuration) IsState(state string) bool {
+	for _, b := range conf.branches {
+		if b.state == state {
+			return true
+		}
+	}
+	return false
+}
+
+// CanNext is false once every branch has died.
+func (conf configuration) CanNext() bool {
+	return len(conf.branches) != 0
+}
+
+// Input: [state, symbol, pop, state, push, ...], a flat list of the transitions to follow.
+// Every branch follows every given transition that it can follow.
+// Branches that are identical to one found before are dropped, so a cycle of ε-transitions dies out.
+func (conf configuration) Next(inputs []string) (machine.Configuration, error) {
+	if len(inputs)%5 != 0 {
+		return nil, errors.New("Illegal configuration.")
+	}
+
+	next_conf := configuration{[]branch{}, conf.depth + 1, conf.seen}
+	found := make(map[string]bool)
+	for _, b := range conf.branches {
+		for i := 0; i < len(inputs); i += 5 {
+			t, err := makeTransition(inputs[i : i+5])
+			if err != nil {
+				return nil, err
+			}
+
+			next, ok := b.follow(t)
+			if !ok {
+				continue
+			}
+
+			// Only keep branches that were not found before this depth,
+			// so stepping the same configuration twice gives the same result.
+			key := next.key()
+			if depth, ok := conf.seen[key]; (ok && depth <= conf.depth) || found[key] {
+				continue
+			}
+			if _, ok := conf.seen[key]; !ok {
+				conf.seen[key] = next_conf.depth
+			}
+			found[key] = true
+			next_conf.branches = append(next_conf.branches, next)
+		}
+	}
+
+	return next_conf, nil
+}
+
+// Output: [state, symbol, top, ...], for every branch.
+// The symbol and top are machine.Epsilon when the input or stack is empty.
+func (conf configuration) GetNext() ([]string, error) {
+	if len(conf.branches) == 0 {
+		return nil, errors.New("Illegal Configuration.")
+	}
+
+	next := make([]string, 0, 3*len(conf.branches))
+	for _, b := range conf.branches {
+		symbol := machine.Epsilon
+		if len(b.input) != 0 {
+			symbol = b.input[0]
+		}
+		top := machine.Epsilon
+		if len(b.stack) != 0 {
+			top = b.stack[len(b.stack)-1]
+		}
+		next = append(next, b.state, symbol, top)
+	}
+	return next, nil
+}
+
+// follow returns the branch after taking the transition, if it can be taken.
+func (b branch) follow(t transition) (branch, bool) {
+	if t.in.state != b.state {
+		return branch{}, false
+	}
+
+	// read the symbol
+	input := b.input
+	if t.in.symbol != machine.Epsilon {
+		if len(input) == 0 || input[0] != t.in.symbol {
+			return branch{}, false
+		}
+		input = input[1:]
+	}
+
+	// Don't want to mutate, other branches share the stack
+	stack := make([]string, len(b.stack), len(b.stack)+len(t.out.push))
+	copy(stack, b.stack)
+
+	// pop the symbol
+	if t.in.pop != machine.Epsilon {
+		if len(stack) == 0 || stack[len(stack)-1] != t.in.pop {
+			return branch{}, false
+		}
+		stack = stack[:len(stack)-1]
+	}
+
+	// push the symbols, last one first so the first one ends up on top
+	for i := len(t.out.push) - 1; i >= 0; i-- {
+		stack = append(stack, t.out.push[i])
+	}
+
+	return branch{t.out.state, input, stack}, true
+}
+
+// key identifies a branch so that duplicates can be dropped.
+func (b branch) key() string {
+	return b.state + "\x00" + strconv.Itoa(len(b.input)) + "\x00" + strings.Join(b.stack, "\x00")
+}
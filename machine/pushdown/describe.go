@@ -0,0 +1,67 @@
+package pushdown
+
+import (
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+func (p pda) Type() string {
+	return machine.PDA
+}
+
+func (p pda) States() []string {
+	return machine.States(p.start, p.Edges(), p.accepts...)
+}
+
+func (p pda) StartState() string {
+	return p.start
+}
+
+func (p pda) AcceptStates() []string {
+	return append([]string{}, p.accepts...)
+}
+
+// Output: space-delimited, with the top first
+func (p pda) StartStack() string {
+	push := make([]string, 0, len(p.startStack))
+	for i := len(p.startStack) - 1; i >= 0; i-- {
+		push = append(push, p.startStack[i])
+	}
+	return strings.Join(push, " ")
+}
+
+func (p pda) AcceptsEmptyStack() bool {
+	return p.emptyStack
+}
+
+// A PDA rejects once every branch dies, so there are no reject states.
+func (p pda) RejectStates() []string {
+	return []string{}
+}
+
+// Output: [[state, symbol, pop, state, push], ...]
+func (p pda) Transitions() [][]string {
+	trans := make([][]string, 0, len(p.trans))
+	for _, t := range p.trans {
+		trans = append(trans, append(t.GetInput(), t.GetOutput()...))
+	}
+	return trans
+}
+
+// Output: [[from, to, "symbol,pop→push"], ...], with anything empty written as "ε"
+func (p pda) Edges() [][]string {
+	epsilon := func(s string) string {
+		if s == machine.Epsilon {
+			return "ε"
+		}
+		return s
+	}
+
+	edges := make([][]string, 0, len(p.trans))
+	for _, t := range p.trans {
+		label := epsilon(t.in.symbol) + "," + epsilon(t.in.pop) + "→" + epsilon(strings.Join(t.out.push, " "))
+		edges = append(edges, []string{t.in.state, t.out.state, label})
+	}
+	return edges
+}
@@ -0,0 +1,16 @@
+package pushdown
+
+import (
+	"fmt"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// Sprint writes the branches of a configuration, leaving out what the search has seen.
+func Sprint(conf machine.Configuration) string {
+	pushdown, ok := conf.(configuration)
+	if !ok {
+		return fmt.Sprint(conf)
+	}
+	return fmt.Sprint(struct{ branches []branch }{pushdown.branches})
+}
@@ -0,0 +1,109 @@
+// Package pushdown provides nondeterministic pushdown automata.
+package pushdown
+
+import (
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+type pda struct {
+	trans      []transition
+	start      string
+	startStack []string // the top of the stack is the last symbol
+	accepts    []string
+	emptyStack bool
+}
+
+// MakePDA is the constructor for a pda.
+// A transition is [state, symbol, pop, state, push], where the symbol and pop can be
+// machine.Epsilon, and push is a space-delimited string whose first symbol ends up on top.
+// The stack starts with startStack, which is written the same way as push.
+// The PDA accepts by final state, and also by empty stack if emptyStack is true.
+func MakePDA(trans [][]string, start string, startStack string, accepts []string, emptyStack bool) (machine.Machine, error) {
+	transitions := []transition{}
+	for i, tran := range trans {
+		t, err := makeTransition(tran)
+		if err != nil {
+			return nil, machine.TransitionError{Index: i, Err: err}
+		}
+		transitions = append(transitions, t)
+	}
+
+	// store the start stack the same way a configuration does, top last
+	push := strings.Fields(startStack)
+	stack := make([]string, 0, len(push))
+	for i := len(push) - 1; i >= 0; i-- {
+		stack = append(stack, push[i])
+	}
+
+	return pda{transitions, start, stack, accepts, emptyStack}, nil
+}
+
+// Start builds the first Configuration given a space-delimited input string.
+func (p pda) Start(input string) machine.Configuration {
+	stack := make([]string, len(p.startStack))
+	copy(stack, p.startStack)
+	start := branch{p.start, strings.Fields(input), stack}
+	return configuration{[]branch{start}, 0, map[string]int{start.key(): 0}}
+}
+
+// Step follows every transition from every branch at once.
+// A branch with no transition to follow dies.
+func (p pda) Step(conf machine.Configuration) (machine.Configuration, error) {
+	next, err := conf.GetNext()
+	if err != nil {
+		return nil, err
+	}
+
+	moves := []string{}
+	found := make([]bool, len(p.trans))
+	for i := 0; i+2 < len(next); i += 3 {
+		state, symbol, top := next[i], next[i+1], next[i+2]
+		for j, t := range p.trans {
+			if found[j] || t.in.state != state {
+				continue
+			}
+			if (t.in.symbol == machine.Epsilon || t.in.symbol == symbol) && (t.in.pop == machine.Epsilon || t.in.pop == top) {
+				found[j] = true
+				moves = append(moves, t.in.state, t.in.symbol, t.in.pop, t.out.state, strings.Join(t.out.push, " "))
+			}
+		}
+	}
+
+	next_conf, err := conf.Next(moves)
+	if err != nil {
+		return nil, err
+	}
+
+	return next_conf, nil
+}
+
+// IsAccept returns true if any branch has read the whole input and is in an accept state,
+// or has an empty stack when accepting by empty stack.
+func (p pda) IsAccept(conf machine.Configuration) bool {
+	pushdown, ok := conf.(configuration)
+	if !ok {
+		return false
+	}
+
+	for _, b := range pushdown.branches {
+		if len(b.input) != 0 {
+			continue
+		}
+		if p.emptyStack && len(b.stack) == 0 {
+			return true
+		}
+		for _, state := range p.accepts {
+			if b.state == state {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsReject returns true if every branch has died.
+func (p pda) IsReject(conf machine.Configuration) bool {
+	return !conf.CanNext()
+}
@@ -0,0 +1,384 @@
+package pushdown_test
+
+import (
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/pushdown"
+)
+
+// MakePDA
+type makePDA struct {
+	trans      [][]string
+	start      string
+	startStack string
+	accepts    []string
+	emptyStack bool
+}
+
+// Start
+type start struct {
+	pda     machine.Machine
+	pdaName string
+	input   string
+	expect  string
+}
+
+// Step
+type step struct {
+	pda     machine.Machine
+	pdaName string
+	input   machine.Configuration
+	expect  string
+}
+
+// IsAccept
+type isAccept struct {
+	pda     machine.Machine
+	pdaName string
+	input   machine.Configuration
+	expect  bool
+}
+
+// IsReject
+type isReject struct {
+	pda     machine.Machine
+	pdaName string
+	input   machine.Configuration
+	expect  bool
+}
+
+var makePDATests []makePDA
+var startTests []start
+var stepTests []step
+var isAcceptTests []isAccept
+var isRejectTests []isReject
+
+func TestMakePDA(t *testing.T) {
+	for _, tc := range makePDATests {
+		got, err := pushdown.MakePDA(tc.trans, tc.start, tc.startStack, tc.accepts, tc.emptyStack)
+		if err != nil {
+			t.Errorf("MakePDA(%v) errored with %s", tc.trans, err)
+		}
+		_, ok := got.(machine.Machine)
+		if !ok {
+			t.Error("Did not create a PDA.")
+		}
+	}
+}
+
+func TestMakePDAError(t *testing.T) {
+	_, err := pushdown.MakePDA([][]string{{"q0", "a", "q1"}}, "q0", "", []string{}, false)
+	if err == nil {
+		t.Error("MakePDA with a transition of the wrong length did not error.")
+	}
+}
+
+func TestStart(t *testing.T) {
+	for _, tc := range startTests {
+		got := pushdown.Sprint(tc.pda.Start(tc.input))
+		if got != tc.expect {
+			t.Errorf("%s.Start(%s) == %v != %s", tc.pdaName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	for _, tc := range stepTests {
+		gotMachine, _ := tc.pda.Step(tc.input)
+		got := pushdown.Sprint(gotMachine)
+		if got != tc.expect {
+			t.Errorf("%s.Step(%v) == %v != %s", tc.pdaName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestIsAccept(t *testing.T) {
+	for _, tc := range isAcceptTests {
+		got := tc.pda.IsAccept(tc.input)
+		if got != tc.expect {
+			t.Errorf("%s.IsAccept(%v) == %t != %t", tc.pdaName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestIsReject(t *testing.T) {
+	for _, tc := range isRejectTests {
+		got := tc.pda.IsReject(tc.input)
+		if got != tc.expect {
+			t.Errorf("%s.IsReject(%v) == %t != %t", tc.pdaName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	conf := anbnPDA.Start("a b")
+	conf, _ = anbnPDA.Step(conf)
+	conf, _ = anbnPDA.Step(conf)
+	expect := "q1: b | A $"
+	if got := conf.Print(); got != expect {
+		t.Errorf("anbnPDA.Print() == %q != %q", got, expect)
+	}
+
+	conf = palindromePDA.Start("a")
+	conf, _ = palindromePDA.Step(conf)
+	conf, _ = palindromePDA.Step(conf)
+	expect = "push: | a $\nmatch: a | $"
+	if got := conf.Print(); got != expect {
+		t.Errorf("palindromePDA.Print() == %q != %q", got, expect)
+	}
+}
+
+// run simulates the PDA until it accepts or rejects.
+func run(p machine.Machine, input string) bool {
+	conf := p.Start(input)
+	for i := 0; i < 1000; i++ {
+		if p.IsAccept(conf) {
+			return true
+		}
+		if p.IsReject(conf) {
+			return false
+		}
+		conf, _ = p.Step(conf)
+	}
+	return false
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		pda     machine.Machine
+		pdaName string
+		input   string
+		expect  bool
+	}{
+		{anbnPDA, "anbnPDA", "", true},
+		{anbnPDA, "anbnPDA", "a b", true},
+		{anbnPDA, "anbnPDA", "a a a b b b", true},
+		{anbnPDA, "anbnPDA", "a a b", false},
+		{anbnPDA, "anbnPDA", "a b b", false},
+		{anbnPDA, "anbnPDA", "b a", false},
+
+		{palindromePDA, "palindromePDA", "", true},
+		{palindromePDA, "palindromePDA", "a b b a", true},
+		{palindromePDA, "palindromePDA", "b a a a a b", true},
+		{palindromePDA, "palindromePDA", "a b a", false},
+		{palindromePDA, "palindromePDA", "a b", false},
+
+		{parensPDA, "parensPDA", "", true},
+		{parensPDA, "parensPDA", "( ( ) ( ) )", true},
+		{parensPDA, "parensPDA", "( ( )", false},
+		{parensPDA, "parensPDA", ") (", false},
+	}
+
+	for _, tc := range tests {
+		got := run(tc.pda, tc.input)
+		if got != tc.expect {
+			t.Errorf("%s on %q accepted == %t != %t", tc.pdaName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+// TestEpsilonCycle checks a PDA rejects once a cycle of ε-transitions stops finding new branches.
+func TestEpsilonCycle(t *testing.T) {
+	tests := []struct {
+		pda     machine.Machine
+		pdaName string
+		input   string
+		accepts bool
+		steps   int
+	}{
+		{selfLoopPDA, "selfLoopPDA", "b", false, 1},
+		{selfLoopPDA, "selfLoopPDA", "a", true, 1},
+		{selfLoopPDA, "selfLoopPDA", "a b", false, 2},
+		{cyclePDA, "cyclePDA", "b", false, 2},
+		{cyclePDA, "cyclePDA", "a", true, 1},
+	}
+
+	for _, tc := range tests {
+		conf := tc.pda.Start(tc.input)
+		steps := 0
+		for ; steps < 100 && !tc.pda.IsAccept(conf) && !tc.pda.IsReject(conf); steps++ {
+			conf, _ = tc.pda.Step(conf)
+		}
+		if tc.pda.IsAccept(conf) != tc.accepts || steps != tc.steps {
+			t.Errorf("%s on %q accepted == %t after %d steps != %t after %d steps", tc.pdaName, tc.input, tc.pda.IsAccept(conf), steps, tc.accepts, tc.steps)
+		}
+	}
+}
+
+// PDAs to test
+
+// recognizes a, with an ε-transition from the start state to itself
+var selfLoopPDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"q0", machine.Epsilon, machine.Epsilon, "q0", ""},
+		{"q0", "a", machine.Epsilon, "q1", ""},
+	},
+	"q0",
+	"",
+	[]string{"q1"},
+	false)
+
+// recognizes a, with a cycle of ε-transitions through two states
+var cyclePDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"q0", machine.Epsilon, machine.Epsilon, "p", ""},
+		{"p", machine.Epsilon, machine.Epsilon, "q0", ""},
+		{"q0", "a", machine.Epsilon, "q1", ""},
+	},
+	"q0",
+	"",
+	[]string{"q1"},
+	false)
+
+// recognizes a^n b^n
+var anbnPDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"q0", machine.Epsilon, machine.Epsilon, "q1", "$"},
+		{"q1", "a", machine.Epsilon, "q1", "A"},
+		{"q1", "b", "A", "q2", ""},
+		{"q2", "b", "A", "q2", ""},
+		{"q2", machine.Epsilon, "$", "q3", ""},
+	},
+	"q0",
+	"",
+	[]string{"q0", "q3"},
+	false)
+
+// recognizes even length palindromes over {a, b}
+var palindromePDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"start", machine.Epsilon, machine.Epsilon, "push", "$"},
+		{"push", "a", machine.Epsilon, "push", "a"},
+		{"push", "b", machine.Epsilon, "push", "b"},
+		{"push", machine.Epsilon, machine.Epsilon, "match", ""},
+		{"match", "a", "a", "match", ""},
+		{"match", "b", "b", "match", ""},
+		{"match", machine.Epsilon, "$", "accept", ""},
+	},
+	"start",
+	"",
+	[]string{"accept"},
+	false)
+
+// recognizes balanced parentheses by empty stack
+var parensPDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"q", "(", machine.Epsilon, "q", "X"},
+		{"q", ")", "X", "q", ""},
+		{"q", machine.Epsilon, "Z", "q", ""},
+	},
+	"q",
+	"Z",
+	[]string{},
+	true)
+
+// pushes two symbols at once
+var pushTwoPDA, _ = pushdown.MakePDA(
+	[][]string{
+		{"q0", "a", machine.Epsilon, "q0", "X Y"},
+	},
+	"q0",
+	"Z",
+	[]string{},
+	false)
+
+// set up the makePDATests automatically
+func init() {
+	makePDATests = []makePDA{
+		{[][]string{}, "start", "", []string{}, false},
+		{[][]string{{"q0", "a", machine.Epsilon, "q0", "A B"}}, "q0", "Z", []string{"q0"}, true},
+	}
+}
+
+// set up the startTests automatically
+func init() {
+	startTests = []start{
+		{anbnPDA, "anbnPDA", "a b", "{[{q0 [a b] []}]}"},
+		{parensPDA, "parensPDA", "", "{[{q [] [Z]}]}"},
+	}
+}
+
+// set up the stepTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+	var step2 machine.Configuration
+	var step3 machine.Configuration
+
+	start = anbnPDA.Start("a b")
+	step1, _ = anbnPDA.Step(start)
+	step2, _ = anbnPDA.Step(step1)
+	step3, _ = anbnPDA.Step(step2)
+	stepTests = append(stepTests, []step{
+		{anbnPDA, "anbnPDA", start, "{[{q1 [a b] [$]}]}"},
+		{anbnPDA, "anbnPDA", step1, "{[{q1 [b] [$ A]}]}"},
+		{anbnPDA, "anbnPDA", step2, "{[{q2 [] [$]}]}"},
+		{anbnPDA, "anbnPDA", step3, "{[{q3 [] []}]}"},
+	}...)
+
+	// branches die when there is no transition to follow
+	start = anbnPDA.Start("b")
+	step1, _ = anbnPDA.Step(start)
+	stepTests = append(stepTests, []step{
+		{anbnPDA, "anbnPDA", step1, "{[]}"},
+	}...)
+
+	// every branch follows every transition
+	start = palindromePDA.Start("a a")
+	step1, _ = palindromePDA.Step(start)
+	step2, _ = palindromePDA.Step(step1)
+	stepTests = append(stepTests, []step{
+		{palindromePDA, "palindromePDA", start, "{[{push [a a] [$]}]}"},
+		{palindromePDA, "palindromePDA", step1, "{[{push [a] [$ a]} {match [a a] [$]}]}"},
+		{palindromePDA, "palindromePDA", step2, "{[{push [] [$ a a]} {match [a] [$ a]} {accept [a a] []}]}"},
+	}...)
+
+	// the first pushed symbol ends up on top
+	start = pushTwoPDA.Start("a a")
+	step1, _ = pushTwoPDA.Step(start)
+	stepTests = append(stepTests, []step{
+		{pushTwoPDA, "pushTwoPDA", start, "{[{q0 [a] [Z Y X]}]}"},
+		{pushTwoPDA, "pushTwoPDA", step1, "{[{q0 [] [Z Y X Y X]}]}"},
+	}...)
+}
+
+// set up the isAcceptTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+
+	start = anbnPDA.Start("")
+	isAcceptTests = append(isAcceptTests, []isAccept{
+		{anbnPDA, "anbnPDA", start, true},
+	}...)
+
+	start = anbnPDA.Start("a")
+	isAcceptTests = append(isAcceptTests, []isAccept{
+		{anbnPDA, "anbnPDA", start, false},
+	}...)
+
+	// accepts by empty stack, not by the start stack
+	start = parensPDA.Start("")
+	step1, _ = parensPDA.Step(start)
+	isAcceptTests = append(isAcceptTests, []isAccept{
+		{parensPDA, "parensPDA", start, false},
+		{parensPDA, "parensPDA", step1, true},
+	}...)
+}
+
+// set up the isRejectTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+	var step2 machine.Configuration
+
+	start = anbnPDA.Start("b")
+	step1, _ = anbnPDA.Step(start)
+	step2, _ = anbnPDA.Step(step1)
+	isRejectTests = append(isRejectTests, []isReject{
+		{anbnPDA, "anbnPDA", start, false},
+		{anbnPDA, "anbnPDA", step1, false},
+		{anbnPDA, "anbnPDA", step2, true},
+	}...)
+}
@@ -0,0 +1,59 @@
+package pushdown
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Transition represents a transition function.
+type transition struct {
+	in  input
+	out output
+}
+
+// Input represents an input to a transition function.
+type input struct {
+	state  string
+	symbol string // machine.Epsilon to not read a symbol
+	pop    string // machine.Epsilon to not pop the stack
+}
+
+// Output represents an output of a transition function.
+type output struct {
+	state string
+	push  []string // the first symbol ends up on top of the stack
+}
+
+func makeTransition(inputs []string) (transition, error) {
+	if len(inputs) != 5 {
+		return transition{}, fmt.Errorf("A transition must have 5 values, not %d.", len(inputs))
+	}
+	return transition{input{inputs[0], inputs[1], inputs[2]}, output{inputs[3], strings.Fields(inputs[4])}}, nil
+}
+
+// Output: [state, symbol, pop]
+func (t transition) GetInput() []string {
+	return []string{t.in.state, t.in.symbol, t.in.pop}
+}
+
+// Output: [state, push]
+func (t transition) GetOutput() []string {
+	return []string{t.out.state, strings.Join(t.out.push, " ")}
+}
+
+// Input: [state, symbol, pop]
+func (t transition) IsInput(inputs []string) (bool, error) {
+	if len(inputs) != 3 {
+		return false, errors.New("Illegal Transition.")
+	}
+	return t.in.state == inputs[0] && t.in.symbol == inputs[1] && t.in.pop == inputs[2], nil
+}
+
+// Input: [state, push]
+func (t transition) IsOutput(inputs []string) (bool, error) {
+	if len(inputs) != 2 {
+		return false, errors.New("Illegal Transition.")
+	}
+	return t.out.state == inputs[0] && strings.Join(t.out.push, " ") == inputs[1], nil
+}
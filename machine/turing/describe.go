@@ -0,0 +1,42 @@
+package turing
+
+import "github.com/cjcodell1/tint/machine"
+
+// States returns every state of a Turing machine, in the order they are first mentioned,
+// given an edge [from, to, label] for each transition. A wildcard is not a state.
+func States(start string, edges [][]string, accept string, reject string) []string {
+	states := []string{}
+	for _, state := range machine.States(start, edges, accept, reject) {
+		if state != machine.Wildcard {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// Edges returns the edges [from, to, label] of the state diagram of a Turing machine, given one for each transition.
+// A transition from any state is drawn from every state but the accept and reject states,
+// and a transition to any state is drawn back to the state it is from.
+func Edges(edges [][]string, states []string, accept string, reject string) [][]string {
+	drawn := [][]string{}
+	for _, edge := range edges {
+		from := []string{edge[0]}
+		if edge[0] == machine.Wildcard {
+			from = []string{}
+			for _, state := range states {
+				if state != accept && state != reject {
+					from = append(from, state)
+				}
+			}
+		}
+
+		for _, state := range from {
+			to := edge[1]
+			if to == machine.Wildcard {
+				to = state
+			}
+			drawn = append(drawn, []string{state, to, edge[2]})
+		}
+	}
+	return drawn
+}
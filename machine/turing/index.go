@@ -0,0 +1,82 @@
+package turing
+
+import "github.com/cjcodell1/tint/machine"
+
+// Index finds the transitions that match a state and symbol without looking at every transition.
+// A transition matches when its state and symbol are the ones read, or are machine.Wildcard.
+// Matching transitions are found in the order they were written, so the first one found is the one a
+// deterministic machine follows.
+type Index struct {
+	exact     map[[2]string][]int // transitions with a state and a symbol
+	anySymbol map[string][]int    // transitions with a state and a wildcard symbol, by state
+	anyState  map[string][]int    // transitions with a wildcard state and a symbol, by symbol
+	any       []int               // transitions with a wildcard state and a wildcard symbol
+}
+
+// MakeIndex indexes transitions by what they read, given as [state, symbol] for each transition in order.
+// The index of a transition is its position in reads.
+func MakeIndex(reads [][2]string) Index {
+	ix := Index{
+		exact:     map[[2]string][]int{},
+		anySymbol: map[string][]int{},
+		anyState:  map[string][]int{},
+	}
+	for i, read := range reads {
+		state, symbol := read[0], read[1]
+		switch {
+		case state != machine.Wildcard && symbol != machine.Wildcard:
+			ix.exact[read] = append(ix.exact[read], i)
+		case state != machine.Wildcard:
+			ix.anySymbol[state] = append(ix.anySymbol[state], i)
+		case symbol != machine.Wildcard:
+			ix.anyState[symbol] = append(ix.anyState[symbol], i)
+		default:
+			ix.any = append(ix.any, i)
+		}
+	}
+	return ix
+}
+
+// candidates are the matching transitions of each kind, each in order.
+func (ix Index) candidates(state string, symbol string) [4][]int {
+	return [4][]int{
+		ix.exact[[2]string{state, symbol}],
+		ix.anySymbol[state],
+		ix.anyState[symbol],
+		ix.any,
+	}
+}
+
+// First returns the index of the first transition that matches the state and symbol, or -1 if none do.
+func (ix Index) First(state string, symbol string) int {
+	first := -1
+	for _, c := range ix.candidates(state, symbol) {
+		if len(c) != 0 && (first == -1 || c[0] < first) {
+			first = c[0]
+		}
+	}
+	return first
+}
+
+// Each calls f with the index of every transition that matches the state and symbol, in order,
+// until f returns false.
+func (ix Index) Each(state string, symbol string, f func(i int) bool) {
+	c := ix.candidates(state, symbol)
+	for {
+		// the next transition is the smallest of the next one of each kind
+		next := -1
+		for k := range c {
+			if len(c[k]) != 0 && (next == -1 || c[k][0] < c[next][0]) {
+				next = k
+			}
+		}
+		if next == -1 {
+			return
+		}
+		i := c[next][0]
+		c[next] = c[next][1:]
+		if !f(i) {
+			return
+		}
+	}
+}
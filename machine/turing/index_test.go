@@ -0,0 +1,99 @@
+package turing_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// First and Each
+type find struct {
+	state  string
+	symbol string
+	first  int
+	each   []int
+}
+
+// reads are what the transitions of a machine read, with every kind of wildcard out of order.
+var reads = [][2]string{
+	{"q0", "a"},
+	{"*", "b"},
+	{"q0", "*"},
+	{"q0", "b"},
+	{"*", "*"},
+	{"q1", "a"},
+	{"*", "a"},
+	{"q0", "a"},
+}
+
+var findTests []find
+
+func init() {
+	findTests = []find{
+		{"q0", "a", 0, []int{0, 2, 4, 6, 7}},
+		{"q0", "b", 1, []int{1, 2, 3, 4}},
+		{"q0", "c", 2, []int{2, 4}},
+		{"q1", "a", 4, []int{4, 5, 6}},
+		{"q1", "b", 1, []int{1, 4}},
+		{"q2", "c", 4, []int{4}},
+	}
+}
+
+func TestFirst(t *testing.T) {
+	index := turing.MakeIndex(reads)
+	for _, tc := range findTests {
+		got := index.First(tc.state, tc.symbol)
+		if got != tc.first {
+			t.Errorf("First(%s, %s) == %d != %d", tc.state, tc.symbol, got, tc.first)
+		}
+	}
+
+	// nothing matches without wildcards
+	index = turing.MakeIndex(reads[:1])
+	if got := index.First("q0", "b"); got != -1 {
+		t.Errorf("First(q0, b) == %d != -1", got)
+	}
+}
+
+func TestEach(t *testing.T) {
+	index := turing.MakeIndex(reads)
+	for _, tc := range findTests {
+		got := []int{}
+		index.Each(tc.state, tc.symbol, func(i int) bool {
+			got = append(got, i)
+			return true
+		})
+		if fmt.Sprint(got) != fmt.Sprint(tc.each) {
+			t.Errorf("Each(%s, %s) == %v != %v", tc.state, tc.symbol, got, tc.each)
+		}
+
+		// stops when f returns false
+		calls := 0
+		index.Each(tc.state, tc.symbol, func(i int) bool {
+			calls++
+			return false
+		})
+		if calls != 1 {
+			t.Errorf("Each(%s, %s) called f %d times after it returned false", tc.state, tc.symbol, calls)
+		}
+	}
+}
+
+func BenchmarkFirst(b *testing.B) {
+	// 1000 states with 10 symbols each, and a wildcard for each state
+	reads := [][2]string{}
+	for q := 0; q < 1000; q++ {
+		for s := 0; s < 10; s++ {
+			reads = append(reads, [2]string{"q" + strconv.Itoa(q), strconv.Itoa(s)})
+		}
+		reads = append(reads, [2]string{"q" + strconv.Itoa(q), "*"})
+	}
+	index := turing.MakeIndex(reads)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.First("q999", "_")
+	}
+}
@@ -0,0 +1,172 @@
+package multi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// tape is a one-way infinite tape and the head on it.
+type tape struct {
+	cells []string
+	head  int
+}
+
+type configuration struct {
+	state string
+	tapes []tape
+}
+
+// Print writes each tape on its own line with its own caret line underneath.
+// The state is written before the first tape.
+func (conf configuration) Print() string {
+	var prefix strings.Builder
+
+	// the WriteString method on a strings.Builder always returns a nil error.
+
+	// add spaces for the state and semicolon
+	for _, _ = range conf.state {
+		prefix.WriteString(" ")
+	}
+	prefix.WriteString(" ")
+
+	lines := make([]string, 0, 2*len(conf.tapes))
+	for i, t := range conf.tapes {
+		var line1 strings.Builder
+		var line2 strings.Builder
+
+		// add state and semicolon, or line up with them
+		if i == 0 {
+			line1.WriteString(conf.state)
+			line1.WriteString(":")
+		} else {
+			line1.WriteString(prefix.String())
+		}
+		line2.WriteString(prefix.String())
+
+		// now write what's on the tape
+		line1.WriteString(" ")
+		line1.WriteString(strings.Join(t.cells, " "))
+
+		carrot := 0
+		for {
+			if carrot == t.head {
+				line2.WriteString("^")
+				break
+			} else {
+				line2.WriteString(" ")
+				for _, _ = range t.cells[carrot] {
+					line2.WriteString(" ")
+				}
+				carrot += 1
+			}
+		}
+
+		// write the last blank
+		line1.WriteString(" ")
+		line1.WriteString(turing.Blank)
+
+		lines = append(lines, line1.String(), line2.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (conf configuration) IsState(state string) bool {
+	return conf.state == state
+}
+
+// State returns the state.
+func (conf configuration) State() string {
+	return conf.state
+}
+
+// Tapes returns each tape and where the head is on it.
+func (conf configuration) Tapes() ([][]string, []int) {
+	tapes := make([][]string, 0, len(conf.tapes))
+	heads := make([]int, 0, len(conf.tapes))
+	for _, t := range conf.tapes {
+		tapes = append(tapes, t.cells)
+		heads = append(heads, t.head)
+	}
+	return tapes, heads
+}
+
+func (conf configuration) CanNext() bool {
+	return true
+}
+
+// Input: [state, symbol..., move...], with a symbol and a move for each tape.
+func (conf configuration) Next(inputs []string) (machine.Configuration, error) {
+	tapes := len(conf.tapes)
+	if len(inputs) != 1+2*tapes {
+		return nil, errors.New("Illegal configuration.")
+	}
+
+	// Assume that conf is not in an accept or a reject state.
+
+	var next_conf configuration
+	// transition to the next state
+	next_conf.state = inputs[0]
+	next_conf.tapes = make([]tape, tapes)
+
+	for i, t := range conf.tapes {
+		next_tape, err := t.next(inputs[1+i], inputs[1+tapes+i])
+		if err != nil {
+			return configuration{}, err
+		}
+		next_conf.tapes[i] = next_tape
+	}
+
+	return next_conf, nil
+}
+
+// Output: [state, symbol...], with the symbol under each head.
+func (conf configuration) GetNext() ([]string, error) {
+	next := []string{conf.state}
+	for _, t := range conf.tapes {
+		if t.head < len(t.cells) {
+			next = append(next, t.cells[t.head])
+		} else {
+			next = append(next, turing.Blank)
+		}
+	}
+	return next, nil
+}
+
+// next writes the symbol under the head and then moves the head.
+func (t tape) next(next_symbol string, next_move string) (tape, error) {
+	// Don't want to mutate
+	prevCells := make([]string, len(t.cells))
+	copy(prevCells, t.cells)
+
+	var next_tape tape
+
+	// write the next symbol
+	if t.head == len(prevCells) {
+		next_tape.cells = append(prevCells, next_symbol)
+	} else {
+		next_tape.cells = prevCells
+		next_tape.cells[t.head] = next_symbol
+	}
+
+	// move in the next direction
+	if (t.head == 0) && (next_move == turing.Left) {
+		next_tape.head = t.head
+	} else {
+		if next_move == turing.Right {
+			next_tape.head = t.head + 1
+		} else if next_move == turing.Left {
+			next_tape.head = t.head - 1
+		} else if (next_move == turing.Stay) || (next_move == turing.NoMove) {
+			next_tape.head = t.head
+		} else {
+			return tape{}, fmt.Errorf("%s is not a legal move, use %s, %s, or %s", next_move, turing.Right, turing.Left, turing.Stay)
+		}
+	}
+
+	return next_tape, nil
+}
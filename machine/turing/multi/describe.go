@@ -0,0 +1,53 @@
+package multi
+
+import (
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+func (tm turingMachine) Type() string {
+	return machine.MULTI_TAPE_TM
+}
+
+func (tm turingMachine) States() []string {
+	return turing.States(tm.start, tm.edges(), tm.accept, tm.reject)
+}
+
+func (tm turingMachine) StartState() string {
+	return tm.start
+}
+
+func (tm turingMachine) AcceptStates() []string {
+	return []string{tm.accept}
+}
+
+func (tm turingMachine) RejectStates() []string {
+	return []string{tm.reject}
+}
+
+// Output: [[state, symbol..., state, symbol..., move...], ...]
+func (tm turingMachine) Transitions() [][]string {
+	trans := make([][]string, 0, len(tm.trans))
+	for _, t := range tm.trans {
+		trans = append(trans, append(t.GetInput(), t.GetOutput()...))
+	}
+	return trans
+}
+
+// Output: [[from, to, "symbol,...→symbol,...,move,..."], ...]
+// A transition from any state is drawn from every state but the accept and reject states,
+// and a transition to any state is drawn back to the state it is from.
+func (tm turingMachine) Edges() [][]string {
+	return turing.Edges(tm.edges(), tm.States(), tm.accept, tm.reject)
+}
+
+// edges returns [from, to, label] for each transition, with the wildcards left in.
+func (tm turingMachine) edges() [][]string {
+	edges := make([][]string, 0, len(tm.trans))
+	for _, t := range tm.trans {
+		edges = append(edges, []string{t.in.state, t.out.state, strings.Join(t.in.symbols, ",") + "→" + strings.Join(t.out.symbols, ",") + "," + strings.Join(t.out.moves, ",")})
+	}
+	return edges
+}
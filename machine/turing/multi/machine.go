@@ -0,0 +1,136 @@
+// Package multi provides Turing machines with any number of one-way infinite tapes.
+package multi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+type turingMachine struct {
+	trans  []transition
+	index  turing.Index // finds the transitions in trans by their state and the symbol they read on the first tape
+	tapes  int
+	start  string
+	accept string
+	reject string
+}
+
+// MakeTuringMachine is the constructor for a turingMachine with the given number of tapes.
+// A transition is [state, symbol..., state, symbol..., move...] with a symbol, symbol and move for each tape.
+// Errors when there are no tapes or when the accept and reject states are the same state.
+func MakeTuringMachine(trans [][]string, tapes int, start string, accept string, reject string) (machine.Machine, error) {
+	if tapes < 1 {
+		return turingMachine{}, machine.FieldError{Field: "tapes", Err: fmt.Errorf("%d is not a legal number of tapes, there must be at least 1.", tapes)}
+	}
+	if accept == reject {
+		return turingMachine{}, machine.FieldError{Field: "reject", Err: fmt.Errorf("%s cannot be both the accept state and the reject state.", accept)}
+	}
+	transitions := []transition{}
+	reads := [][2]string{}
+	for i, tran := range trans {
+		t, err := makeTransition(tran, tapes)
+		if err != nil {
+			return nil, machine.TransitionError{Index: i, Err: err}
+		}
+		transitions = append(transitions, t)
+		reads = append(reads, [2]string{t.in.state, t.in.symbols[0]})
+	}
+	return turingMachine{transitions, turing.MakeIndex(reads), tapes, start, accept, reject}, nil
+}
+
+// Start builds the first Config given a space-delimited input string.
+// The input is placed on the first tape and every other tape is blank.
+func (tm turingMachine) Start(input string) machine.Configuration {
+	tapes := make([]tape, tm.tapes)
+	tapes[0] = tape{strings.Fields(input), 0}
+	for i := 1; i < tm.tapes; i++ {
+		tapes[i] = tape{[]string{}, 0}
+	}
+	return configuration{tm.start, tapes}
+}
+
+// Step applies one transition to the given Config.
+// Applies no transition if the Config is in an accept or reject state.
+// Errors when there is no transition for the Config.
+func (tm turingMachine) Step(conf machine.Configuration) (machine.Configuration, error) {
+
+	// if the state is accept or reject, then don't do anything
+	if tm.IsAccept(conf) || tm.IsReject(conf) {
+		return conf, nil
+	}
+
+	next, err := conf.GetNext()
+	if err != nil {
+		return configuration{}, err
+	}
+	if len(next) != 1+tm.tapes {
+		return configuration{}, errors.New("Illegal configuration.")
+	}
+	state := next[0]
+	symbols := next[1:]
+
+	outputs, err := tm.findTransition(state, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	next_conf, err := conf.Next(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return next_conf, nil
+}
+
+// IsAccept returns true if the Config is in an accept state.
+func (tm turingMachine) IsAccept(conf machine.Configuration) bool {
+	return conf.IsState(tm.accept)
+}
+
+// IsReject returns true if the Config is in a reject state.
+func (tm turingMachine) IsReject(conf machine.Configuration) bool {
+	return conf.IsState(tm.reject)
+}
+
+// findTransition returns [state, symbol..., move...] of the first transition matching the state and symbols.
+func (tm turingMachine) findTransition(state string, symbols []string) ([]string, error) {
+	// the index only matches the first tape, so the rest are checked here
+	found := -1
+	tm.index.Each(state, symbols[0], func(i int) bool {
+		for j, symbol := range symbols[1:] {
+			in := tm.trans[i].in.symbols[1+j]
+			if (in != symbol) && (in != machine.Wildcard) {
+				return true
+			}
+		}
+		found = i
+		return false
+	})
+
+	if found == -1 {
+		// no transition found
+		err := fmt.Errorf("no transition found for state: \"%s\" and symbols: \"%s\"", state, strings.Join(symbols, "\", \""))
+		return nil, err
+	}
+
+	trans := tm.trans[found]
+	next := make([]string, 0, 1+2*tm.tapes)
+	if trans.out.state == machine.Wildcard {
+		next = append(next, state)
+	} else {
+		next = append(next, trans.out.state)
+	}
+	for i, outSymbol := range trans.out.symbols {
+		if outSymbol == machine.Wildcard {
+			next = append(next, symbols[i]) // if the output symbol is a wildcard, then re-write the symbol that is on the tape
+		} else {
+			next = append(next, outSymbol)
+		}
+	}
+	next = append(next, trans.out.moves...)
+	return next, nil
+}
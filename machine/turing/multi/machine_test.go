@@ -0,0 +1,252 @@
+package multi_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/multi"
+)
+
+// MakeTuringMachine
+type makeTuringMachine struct {
+	trans  [][]string
+	tapes  int
+	start  string
+	accept string
+	reject string
+	isErr  bool
+}
+
+// Start
+type start struct {
+	tm     machine.Machine
+	tmName string
+	input  string
+	expect string
+}
+
+// Step
+type step struct {
+	tm     machine.Machine
+	tmName string
+	input  machine.Configuration
+	expect string
+}
+
+// IsAccept
+type isAccept struct {
+	tm     machine.Machine
+	tmName string
+	input  machine.Configuration
+	expect bool
+}
+
+// IsReject
+type isReject struct {
+	tm     machine.Machine
+	tmName string
+	input  machine.Configuration
+	expect bool
+}
+
+var makeTuringMachineTests []makeTuringMachine
+var startTests []start
+var stepTests []step
+var isAcceptTests []isAccept
+var isRejectTests []isReject
+
+func TestMakeTuringMachine(t *testing.T) {
+	for _, tc := range makeTuringMachineTests {
+		got, err := multi.MakeTuringMachine(tc.trans, tc.tapes, tc.start, tc.accept, tc.reject)
+		if (err != nil) != tc.isErr {
+			t.Errorf("MakeTuringMachine(%v, %d) errored with %v", tc.trans, tc.tapes, err)
+		}
+		if err != nil {
+			continue
+		}
+		_, ok := got.(machine.Machine)
+		if !ok {
+			t.Error("Did not create a Turing Machine.")
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	for _, tc := range startTests {
+		got := fmt.Sprint(tc.tm.Start(tc.input))
+		if got != tc.expect {
+			t.Errorf("%s.Start(%s) == %v != %s", tc.tmName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	for _, tc := range stepTests {
+		gotMachine, _ := tc.tm.Step(tc.input)
+		got := fmt.Sprint(gotMachine)
+		if got != tc.expect {
+			t.Errorf("%s.Step(%v) == %v != %s", tc.tmName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestIsAccept(t *testing.T) {
+	for _, tc := range isAcceptTests {
+		got := tc.tm.IsAccept(tc.input)
+		if got != tc.expect {
+			t.Errorf("%s.IsAccept(%v) == %t != %t", tc.tmName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestIsReject(t *testing.T) {
+	for _, tc := range isRejectTests {
+		got := tc.tm.IsReject(tc.input)
+		if got != tc.expect {
+			t.Errorf("%s.IsReject(%v) == %t != %t", tc.tmName, tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	conf := copyTM.Start("a b")
+	conf, _ = copyTM.Step(conf)
+	expect := "" +
+		"copy: a b _\n" +
+		"       ^\n" +
+		"      a _\n" +
+		"       ^"
+	if got := conf.Print(); got != expect {
+		t.Errorf("copyTM.Print() == %q != %q", got, expect)
+	}
+}
+
+func TestNoTransition(t *testing.T) {
+	conf := anbnTM.Start("c")
+	_, err := anbnTM.Step(conf)
+	if err == nil {
+		t.Error("anbnTM.Step() with no transition did not error.")
+	}
+}
+
+// Turing machines to test
+
+// copies the first tape onto the second tape
+var copyTM, _ = multi.MakeTuringMachine(
+	[][]string{
+		{"copy", turing.Blank, turing.Blank, "accept", turing.Blank, turing.Blank, turing.Left, turing.Left},
+		{"copy", "a", turing.Blank, "copy", "a", "a", turing.Right, turing.Right},
+		{"copy", "b", turing.Blank, "copy", "b", "b", turing.Right, turing.Right},
+	},
+	2,
+	"copy",
+	"accept",
+	"reject")
+
+// recognizes a^n b^n by counting the "a"s on the second tape
+var anbnTM, _ = multi.MakeTuringMachine(
+	[][]string{
+		{"start", turing.Blank, turing.Blank, "accept", turing.Blank, turing.Blank, turing.Right, turing.Right},
+		{"start", "a", turing.Blank, "count", "a", "$", turing.Right, turing.Right},
+		{"start", "b", "*", "reject", "b", "*", turing.Right, turing.Right},
+
+		{"count", "a", turing.Blank, "count", "a", "X", turing.Right, turing.Right},
+		{"count", "b", turing.Blank, "match", "b", turing.Blank, turing.Right, turing.Left},
+		{"count", turing.Blank, "*", "reject", turing.Blank, "*", turing.Right, turing.Right},
+
+		{"match", "b", "X", "match", "b", "X", turing.Right, turing.Left},
+		{"match", turing.Blank, "$", "accept", turing.Blank, "$", turing.Right, turing.Right},
+		{"match", "*", "*", "reject", "*", "*", turing.Right, turing.Right},
+	},
+	2,
+	"start",
+	"accept",
+	"reject")
+
+// stays on the first tape and moves on the second
+var stayTM, _ = multi.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", turing.Blank, "q1", "b", "x", turing.Stay, turing.Right},
+		{"q1", "b", turing.Blank, "accept", "c", "y", turing.NoMove, turing.NoMove},
+	},
+	2,
+	"q0",
+	"accept",
+	"reject")
+
+// set up the makeTuringMachineTests automatically
+func init() {
+	makeTuringMachineTests = []makeTuringMachine{
+		{[][]string{}, 1, "start", "accept", "reject", false},
+		{[][]string{{"q0", "*", "*", "accept", "*", "*", turing.Right, turing.Left}}, 2, "q0", "accept", "reject", false},
+		{[][]string{{"q0", "*", "accept", "*", turing.Right}}, 2, "q0", "accept", "reject", true},
+		{[][]string{}, 0, "start", "accept", "reject", true},
+		{[][]string{}, 3, "start", "done", "done", true},
+	}
+}
+
+// set up the startTests automatically
+func init() {
+	startTests = []start{
+		{copyTM, "copyTM", "a b", "{copy [{[a b] 0} {[] 0}]}"},
+		{anbnTM, "anbnTM", "", "{start [{[] 0} {[] 0}]}"},
+	}
+}
+
+// set up the stepTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+	var step2 machine.Configuration
+
+	start = copyTM.Start("a b")
+	step1, _ = copyTM.Step(start)
+	step2, _ = copyTM.Step(step1)
+	stepTests = append(stepTests, []step{
+		{copyTM, "copyTM", start, "{copy [{[a b] 1} {[a] 1}]}"},
+		{copyTM, "copyTM", step1, "{copy [{[a b] 2} {[a b] 2}]}"},
+		{copyTM, "copyTM", step2, "{accept [{[a b _] 1} {[a b _] 1}]}"},
+	}...)
+
+	start = anbnTM.Start("a a b b")
+	step1, _ = anbnTM.Step(start)
+	step2, _ = anbnTM.Step(step1)
+	stepTests = append(stepTests, []step{
+		{anbnTM, "anbnTM", start, "{count [{[a a b b] 1} {[$] 1}]}"},
+		{anbnTM, "anbnTM", step1, "{count [{[a a b b] 2} {[$ X] 2}]}"},
+		{anbnTM, "anbnTM", step2, "{match [{[a a b b] 3} {[$ X _] 1}]}"},
+	}...)
+
+	start = stayTM.Start("a")
+	step1, _ = stayTM.Step(start)
+	stepTests = append(stepTests, []step{
+		{stayTM, "stayTM", start, "{q1 [{[b] 0} {[x] 1}]}"},
+		{stayTM, "stayTM", step1, "{accept [{[c] 0} {[x y] 1}]}"},
+	}...)
+}
+
+// set up the isAcceptTests automatically
+func init() {
+	var conf machine.Configuration
+
+	for _, tc := range []struct {
+		input  string
+		expect bool
+	}{
+		{"", true},
+		{"a b", true},
+		{"a a a b b b", true},
+		{"a a b", false},
+		{"a b b", false},
+		{"b a", false},
+	} {
+		conf = anbnTM.Start(tc.input)
+		for i := 0; i < 100 && !anbnTM.IsAccept(conf) && !anbnTM.IsReject(conf); i++ {
+			conf, _ = anbnTM.Step(conf)
+		}
+		isAcceptTests = append(isAcceptTests, isAccept{anbnTM, "anbnTM", conf, tc.expect})
+		isRejectTests = append(isRejectTests, isReject{anbnTM, "anbnTM", conf, !tc.expect})
+	}
+}
@@ -0,0 +1,87 @@
+package multi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// Transition represents a transition function.
+type transition struct {
+	in  input
+	out output
+}
+
+// Input represents an input to a transition function.
+type input struct {
+	state   string
+	symbols []string // one for each tape
+}
+
+// Output represents an output of a transition function.
+type output struct {
+	state   string
+	symbols []string // one for each tape
+	moves   []string // one for each tape
+}
+
+// makeTransition makes a transition for a Turing machine with the given number of tapes.
+// Input: [state, symbol..., state, symbol..., move...]
+func makeTransition(inputs []string, tapes int) (transition, error) {
+	if len(inputs) != 2+3*tapes {
+		return transition{}, fmt.Errorf("A transition for %d tapes must have %d values, not %d.", tapes, 2+3*tapes, len(inputs))
+	}
+	for _, move := range inputs[2+2*tapes:] {
+		if err := turing.CheckMove(move); err != nil {
+			return transition{}, err
+		}
+	}
+	in := input{inputs[0], inputs[1 : 1+tapes]}
+	out := output{inputs[1+tapes], inputs[2+tapes : 2+2*tapes], inputs[2+2*tapes:]}
+	return transition{in, out}, nil
+}
+
+// Output: [state, symbol...]
+func (t transition) GetInput() []string {
+	return append([]string{t.in.state}, t.in.symbols...)
+}
+
+// Output: [state, symbol..., move...]
+func (t transition) GetOutput() []string {
+	out := append([]string{t.out.state}, t.out.symbols...)
+	return append(out, t.out.moves...)
+}
+
+// Input: [state, symbol...]
+func (t transition) IsInput(inputs []string) (bool, error) {
+	if len(inputs) != 1+len(t.in.symbols) {
+		return false, errors.New("Illegal Transition.")
+	}
+	if t.in.state != inputs[0] {
+		return false, nil
+	}
+	for i, symbol := range t.in.symbols {
+		if symbol != inputs[1+i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Input: [state, symbol..., move...]
+func (t transition) IsOutput(inputs []string) (bool, error) {
+	tapes := len(t.out.symbols)
+	if len(inputs) != 1+2*tapes {
+		return false, errors.New("Illegal Transition.")
+	}
+	if t.out.state != inputs[0] {
+		return false, nil
+	}
+	for i := 0; i < tapes; i++ {
+		if t.out.symbols[i] != inputs[1+i] || t.out.moves[i] != inputs[1+tapes+i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,188 @@
+package ntm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// branch is one way the nondeterministic Turing machine could have run so far.
+type branch struct {
+	state  string
+	tape   []string
+	head   int
+	parent *branch // the branch this one came from, nil for the start
+}
+
+// configuration holds every branch at the same depth of the breadth-first search.
+type configuration struct {
+	branches []*branch
+	depth    int
+	seen     map[string]int // the depth each configuration was first found at, shared by the whole search
+}
+
+// Print writes each branch like a one-way Turing machine configuration.
+func (conf configuration) Print() string {
+	lines := make([]string, 0, len(conf.branches))
+	for _, b := range conf.branches {
+		lines = append(lines, b.print())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsState checks if any branch is in the given state.
+func (conf configuration) IsState(state string) bool {
+	for _, b := range conf.branches {
+		if b.state == state {
+			return true
+		}
+	}
+	return false
+}
+
+// CanNext is false once every branch has died.
+func (conf configuration) CanNext() bool {
+	return len(conf.branches) != 0
+}
+
+// Input: [branch, state, symbol, move, ...], a flat list of the transitions each branch takes.
+// Branches that are identical to one found before are dropped.
+func (conf configuration) Next(inputs []string) (machine.Configuration, error) {
+	if len(inputs)%4 != 0 {
+		return nil, errors.New("Illegal configuration.")
+	}
+
+	next_conf := configuration{[]*branch{}, conf.depth + 1, conf.seen}
+	found := make(map[string]bool)
+	for i := 0; i < len(inputs); i += 4 {
+		index, err := strconv.Atoi(inputs[i])
+		if err != nil || index < 0 || index >= len(conf.branches) {
+			return nil, errors.New("Illegal configuration.")
+		}
+
+		next, err := conf.branches[index].next(inputs[i+1], inputs[i+2], inputs[i+3])
+		if err != nil {
+			return nil, err
+		}
+
+		// Only keep configurations that were not found before this depth,
+		// so stepping the same configuration twice gives the same result.
+		key := next.key()
+		if depth, ok := conf.seen[key]; (ok && depth <= conf.depth) || found[key] {
+			continue
+		}
+		if _, ok := conf.seen[key]; !ok {
+			conf.seen[key] = next_conf.depth
+		}
+		found[key] = true
+		next_conf.branches = append(next_conf.branches, next)
+	}
+
+	return next_conf, nil
+}
+
+// Output: [state, symbol, ...], for every branch.
+func (conf configuration) GetNext() ([]string, error) {
+	next := make([]string, 0, 2*len(conf.branches))
+	for _, b := range conf.branches {
+		if b.head < len(b.tape) {
+			next = append(next, b.state, b.tape[b.head])
+		} else {
+			next = append(next, b.state, turing.Blank)
+		}
+	}
+	return next, nil
+}
+
+// path returns the configurations from the start to the branch.
+func (b *branch) path() []machine.Configuration {
+	path := []machine.Configuration{}
+	for ; b != nil; b = b.parent {
+		path = append([]machine.Configuration{configuration{[]*branch{b}, 0, make(map[string]int)}}, path...)
+	}
+	return path
+}
+
+func (b *branch) print() string {
+	var line1 strings.Builder
+	var line2 strings.Builder
+
+	// the WriteString method on a strings.Builder always returns a nil error.
+
+	// add state and semicolon
+	line1.WriteString(b.state)
+	line1.WriteString(":")
+
+	// add spaces for the state and semicolon
+	for _, _ = range b.state {
+		line2.WriteString(" ")
+	}
+	line2.WriteString(" ")
+
+	// now write what's on the tape
+	line1.WriteString(" ")
+	line1.WriteString(strings.Join(b.tape, " "))
+
+	carrot := 0
+	for {
+		if carrot == b.head {
+			line2.WriteString("^")
+			break
+		} else {
+			line2.WriteString(" ")
+			for _, _ = range b.tape[carrot] {
+				line2.WriteString(" ")
+			}
+			carrot += 1
+		}
+	}
+
+	// write the last blank
+	line1.WriteString(" ")
+	line1.WriteString(turing.Blank)
+
+	return line1.String() + "\n" + line2.String()
+}
+
+// next writes the symbol, moves the head, and changes the state, like a one-way Turing machine.
+func (b *branch) next(next_state string, next_symbol string, next_move string) (*branch, error) {
+	// Don't want to mutate, the parent keeps its tape
+	prevTape := make([]string, len(b.tape))
+	copy(prevTape, b.tape)
+
+	next_b := &branch{state: next_state, parent: b}
+
+	// write the next symbol
+	if b.head == len(prevTape) {
+		next_b.tape = append(prevTape, next_symbol)
+	} else {
+		next_b.tape = prevTape
+		next_b.tape[b.head] = next_symbol
+	}
+
+	// move in the next direction
+	if (b.head == 0) && (next_move == turing.Left) {
+		next_b.head = b.head
+	} else {
+		if next_move == turing.Right {
+			next_b.head = b.head + 1
+		} else if next_move == turing.Left {
+			next_b.head = b.head - 1
+		} else if (next_move == turing.Stay) || (next_move == turing.NoMove) {
+			next_b.head = b.head
+		} else {
+			return nil, fmt.Errorf("%s is not a legal move, use %s, %s, or %s", next_move, turing.Right, turing.Left, turing.Stay)
+		}
+	}
+
+	return next_b, nil
+}
+
+// key identifies the configuration of a branch, regardless of how it was reached.
+func (b *branch) key() string {
+	return b.state + "\x00" + strconv.Itoa(b.head) + "\x00" + strings.Join(b.tape, "\x00")
+}
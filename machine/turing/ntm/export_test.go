@@ -0,0 +1,8 @@
+package ntm
+
+import "github.com/cjcodell1/tint/machine"
+
+// MakeTransition lets the tests check a transition on its own.
+func MakeTransition(trans []string) (machine.Transition, error) {
+	return makeTransition(trans)
+}
@@ -0,0 +1,139 @@
+// Package ntm provides nondeterministic Turing machines with a one-way infinite tape,
+// simulated with a breadth-first search of every branch.
+package ntm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+type turingMachine struct {
+	trans  []transition
+	index  turing.Index // finds the transitions in trans by what they read
+	start  string
+	accept string
+	reject string
+}
+
+// MakeTuringMachine is the constructor for a turingMachine.
+// It provides error checking necessary for a Turing machine.
+// Errors when the accept and reject states are the same state.
+func MakeTuringMachine(trans [][]string, start string, accept string, reject string) (machine.Machine, error) {
+	if accept == reject {
+		return turingMachine{}, machine.FieldError{Field: "reject", Err: fmt.Errorf("%s cannot be both the accept state and the reject state.", accept)}
+	}
+	transitions := []transition{}
+	reads := [][2]string{}
+	for i, tran := range trans {
+		t, err := makeTransition(tran)
+		if err != nil {
+			return nil, machine.TransitionError{Index: i, Err: err}
+		}
+		transitions = append(transitions, t)
+		reads = append(reads, [2]string{t.in.state, t.in.symbol})
+	}
+	return turingMachine{transitions, turing.MakeIndex(reads), start, accept, reject}, nil
+}
+
+// Start builds the first Config given a space-delimited input string.
+func (tm turingMachine) Start(input string) machine.Configuration {
+	start := &branch{tm.start, strings.Fields(input), 0, nil}
+	return configuration{[]*branch{start}, 0, map[string]int{start.key(): 0}}
+}
+
+// Step takes every matching transition on every branch, one level deeper in the search.
+// Applies no transition if a branch is in the accept state.
+// A branch in the reject state or without a matching transition dies.
+func (tm turingMachine) Step(conf machine.Configuration) (machine.Configuration, error) {
+
+	// if a branch accepts, then don't do anything
+	if tm.IsAccept(conf) {
+		return conf, nil
+	}
+
+	next, err := conf.GetNext()
+	if err != nil {
+		return configuration{}, err
+	}
+	if len(next)%2 != 0 {
+		return configuration{}, errors.New("Illegal configuration.")
+	}
+
+	moves := []string{}
+	for i := 0; i < len(next); i += 2 {
+		state := next[i]
+		symbol := next[i+1]
+		if state == tm.reject {
+			continue
+		}
+		for _, out := range tm.findTransitions(state, symbol) {
+			moves = append(moves, strconv.Itoa(i/2))
+			moves = append(moves, out...)
+		}
+	}
+
+	next_conf, err := conf.Next(moves)
+	if err != nil {
+		return nil, err
+	}
+
+	return next_conf, nil
+}
+
+// IsAccept returns true if any branch is in the accept state.
+func (tm turingMachine) IsAccept(conf machine.Configuration) bool {
+	return conf.IsState(tm.accept)
+}
+
+// IsReject returns true if every branch is in the reject state, or every branch has died.
+func (tm turingMachine) IsReject(conf machine.Configuration) bool {
+	nondeterministic, ok := conf.(configuration)
+	if !ok {
+		return false
+	}
+	for _, b := range nondeterministic.branches {
+		if b.state != tm.reject {
+			return false
+		}
+	}
+	return true
+}
+
+// AcceptingPath returns the Configurations from the start to the first branch in the accept state.
+// Returns nil if no branch is in the accept state.
+func (tm turingMachine) AcceptingPath(conf machine.Configuration) []machine.Configuration {
+	nondeterministic, ok := conf.(configuration)
+	if !ok {
+		return nil
+	}
+	for _, b := range nondeterministic.branches {
+		if b.state == tm.accept {
+			return b.path()
+		}
+	}
+	return nil
+}
+
+// findTransitions returns [state, symbol, move] for every transition matching the state and symbol.
+func (tm turingMachine) findTransitions(state string, symbol string) [][]string {
+	found := [][]string{}
+	tm.index.Each(state, symbol, func(i int) bool {
+		trans := tm.trans[i]
+		next_state := trans.out.state
+		if next_state == machine.Wildcard {
+			next_state = state
+		}
+		next_symbol := trans.out.symbol
+		if next_symbol == machine.Wildcard {
+			next_symbol = symbol // if the output symbol is a wildcard, then re-write the symbol that is on the tape
+		}
+		found = append(found, []string{next_state, next_symbol, trans.out.move})
+		return true
+	})
+	return found
+}
@@ -0,0 +1,222 @@
+package ntm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/ntm"
+)
+
+// MakeTuringMachine
+type makeTuringMachine struct {
+	trans  [][]string
+	start  string
+	accept string
+	reject string
+	isErr  bool
+}
+
+// Step
+type step struct {
+	tm     machine.Machine
+	tmName string
+	input  machine.Configuration
+	expect string
+}
+
+// Run
+type run struct {
+	tm     machine.Machine
+	tmName string
+	input  string
+	expect bool
+}
+
+// IsOutput
+type isOutput struct {
+	trans  []string
+	output []string
+	expect bool
+}
+
+var makeTuringMachineTests []makeTuringMachine
+var isOutputTests []isOutput
+var stepTests []step
+var runTests []run
+
+func TestMakeTuringMachine(t *testing.T) {
+	for _, tc := range makeTuringMachineTests {
+		got, err := ntm.MakeTuringMachine(tc.trans, tc.start, tc.accept, tc.reject)
+		if (err != nil) != tc.isErr {
+			t.Errorf("MakeTuringMachine(%v) errored with %v", tc.trans, err)
+		}
+		if err != nil {
+			continue
+		}
+		_, ok := got.(machine.Nondeterministic)
+		if !ok {
+			t.Error("Did not create a nondeterministic Turing Machine.")
+		}
+	}
+}
+
+func TestIsOutput(t *testing.T) {
+	for _, tc := range isOutputTests {
+		trans, err := ntm.MakeTransition(tc.trans)
+		if err != nil {
+			t.Fatalf("MakeTransition(%v) errored: %s", tc.trans, err)
+		}
+		got, _ := trans.IsOutput(tc.output)
+		if got != tc.expect {
+			t.Errorf("%v.IsOutput(%v) == %t != %t", tc.trans, tc.output, got, tc.expect)
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	for _, tc := range stepTests {
+		gotMachine, _ := tc.tm.Step(tc.input)
+		got := gotMachine.Print()
+		if got != tc.expect {
+			t.Errorf("%s.Step(%q) == %q != %q", tc.tmName, tc.input.Print(), got, tc.expect)
+		}
+	}
+}
+
+// simulate runs the Turing machine until it accepts or rejects, and returns the last Configuration.
+func simulate(tm machine.Machine, input string) machine.Configuration {
+	conf := tm.Start(input)
+	for i := 0; i < 1000; i++ {
+		if tm.IsAccept(conf) || tm.IsReject(conf) {
+			break
+		}
+		conf, _ = tm.Step(conf)
+	}
+	return conf
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range runTests {
+		conf := simulate(tc.tm, tc.input)
+		if tc.tm.IsAccept(conf) != tc.expect || tc.tm.IsReject(conf) == tc.expect {
+			t.Errorf("%s on %q accepted == %t, rejected == %t, expected to accept == %t",
+				tc.tmName, tc.input, tc.tm.IsAccept(conf), tc.tm.IsReject(conf), tc.expect)
+		}
+	}
+}
+
+func TestAcceptingPath(t *testing.T) {
+	nondeterministic := abSubstringTM.(machine.Nondeterministic)
+
+	conf := simulate(abSubstringTM, "a a b")
+	path := nondeterministic.AcceptingPath(conf)
+	got := []string{}
+	for _, c := range path {
+		got = append(got, c.Print())
+	}
+	expect := []string{
+		"scan: a a b _\n     ^",
+		"scan: a a b _\n       ^",
+		"check: a a b _\n          ^",
+		"accept: a a b _\n             ^",
+	}
+	if strings.Join(got, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("AcceptingPath() == %q != %q", got, expect)
+	}
+
+	conf = simulate(abSubstringTM, "b a")
+	if path := nondeterministic.AcceptingPath(conf); path != nil {
+		t.Errorf("AcceptingPath() of a rejecting configuration == %v != nil", path)
+	}
+}
+
+func TestStepTwice(t *testing.T) {
+	start := abSubstringTM.Start("a b")
+	first, _ := abSubstringTM.Step(start)
+	second, _ := abSubstringTM.Step(start)
+	if first.Print() != second.Print() {
+		t.Errorf("Stepping the same configuration twice gave %q and %q", first.Print(), second.Print())
+	}
+}
+
+// Turing machines to test
+
+// recognizes strings with "a b" as a substring by guessing where it starts
+var abSubstringTM, _ = ntm.MakeTuringMachine(
+	[][]string{
+		{"scan", "a", "scan", "a", turing.Right},
+		{"scan", "b", "scan", "b", turing.Right},
+		{"scan", "a", "check", "a", turing.Right},
+		{"check", "b", "accept", "b", turing.Right},
+	},
+	"scan",
+	"accept",
+	"reject")
+
+// loops in place forever on "a", unless it guesses to accept on "b"
+var loopTM, _ = ntm.MakeTuringMachine(
+	[][]string{
+		{"start", "a", "start", "a", turing.Left},
+		{"start", "b", "accept", "b", turing.Right},
+		{"start", "b", "start", "b", turing.Left},
+	},
+	"start",
+	"accept",
+	"reject")
+
+// set up the makeTuringMachineTests automatically
+func init() {
+	makeTuringMachineTests = []makeTuringMachine{
+		{[][]string{}, "start", "accept", "reject", false},
+		{[][]string{{"q0", "*", "accept", "*", turing.Right}, {"q0", "*", "reject", "*", turing.Right}}, "q0", "accept", "reject", false},
+		{[][]string{{"q0", "*", "accept"}}, "q0", "accept", "reject", true},
+		{[][]string{}, "start", "done", "done", true},
+	}
+}
+
+// set up the stepTests automatically
+func init() {
+	var start machine.Configuration
+	var step1 machine.Configuration
+
+	start = abSubstringTM.Start("a b")
+	step1, _ = abSubstringTM.Step(start)
+	stepTests = append(stepTests, []step{
+		{abSubstringTM, "abSubstringTM", start, "scan: a b _\n       ^\ncheck: a b _\n        ^"},
+		{abSubstringTM, "abSubstringTM", step1, "scan: a b _\n         ^\naccept: a b _\n           ^"},
+	}...)
+
+	// the same configuration is only found once
+	start = loopTM.Start("a")
+	step1, _ = loopTM.Step(start)
+	stepTests = append(stepTests, []step{
+		{loopTM, "loopTM", start, ""},
+	}...)
+}
+
+// set up the runTests automatically
+func init() {
+	runTests = []run{
+		{abSubstringTM, "abSubstringTM", "a b", true},
+		{abSubstringTM, "abSubstringTM", "b b a a b a", true},
+		{abSubstringTM, "abSubstringTM", "b a", false},
+		{abSubstringTM, "abSubstringTM", "", false},
+
+		{loopTM, "loopTM", "a a", false},
+		{loopTM, "loopTM", "b", true},
+	}
+}
+
+func init() {
+	isOutputTests = []isOutput{
+		{[]string{"q0", "a", "q1", "b", turing.Right}, []string{"q1", "b", turing.Right}, true},
+		{[]string{"q0", "a", "q1", "b", turing.Right}, []string{"q1", "b", turing.Left}, false},
+		{[]string{"q0", "a", "q1", "b", turing.Right}, []string{"q1", "c", turing.Right}, false},
+		{[]string{"q0", "a", "q1", "b", turing.Right}, []string{"q2", "b", turing.Right}, false},
+		// the move is not the symbol
+		{[]string{"q0", "a", "q1", turing.Right, turing.Left}, []string{"q1", turing.Right, turing.Right}, false},
+		{[]string{"q0", "a", "q1", turing.Right, turing.Left}, []string{"q1", turing.Right, turing.Left}, true},
+	}
+}
@@ -1,10 +1,23 @@
 package turing
 
+import "fmt"
+
 const (
 	Blank string = "_"
 )
 
 const (
-	Left  string = "L"
-	Right string = "R"
+	Left   string = "L"
+	Right  string = "R"
+	Stay   string = "S"
+	NoMove string = "N" // the same as Stay
 )
+
+// CheckMove errors if the move is not Left, Right, Stay, or NoMove.
+func CheckMove(move string) error {
+	switch move {
+	case Left, Right, Stay, NoMove:
+		return nil
+	}
+	return fmt.Errorf("%s is not a legal move, use %s, %s, or %s.", move, Right, Left, Stay)
+}
@@ -60,6 +60,16 @@ func (conf configuration) IsState(state string) bool {
 	return conf.state == state
 }
 
+// State returns the state.
+func (conf configuration) State() string {
+	return conf.state
+}
+
+// Tapes returns the tape and where the head is on it.
+func (conf configuration) Tapes() ([][]string, []int) {
+	return [][]string{conf.tape}, []int{conf.head}
+}
+
 func (conf configuration) CanNext() bool {
 	return true
 }
@@ -106,8 +116,10 @@ func (conf configuration) Next(inputs []string) (machine.Configuration, error) {
 			next_conf.head = head + 1
 		} else if next_move == turing.Left {
 			next_conf.head = head - 1
+		} else if (next_move == turing.Stay) || (next_move == turing.NoMove) {
+			next_conf.head = head
 		} else {
-			return configuration{}, fmt.Errorf("%s is not a legal move, use %s or %s", next_move, turing.Right, turing.Left)
+			return configuration{}, fmt.Errorf("%s is not a legal move, use %s, %s, or %s", next_move, turing.Right, turing.Left, turing.Stay)
 		}
 	}
 
@@ -6,10 +6,12 @@ import (
 	"strings"
 
 	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
 )
 
 type turingMachine struct {
 	trans  []transition
+	index  turing.Index // finds the transitions in trans by what they read
 	start  string
 	accept string
 	reject string
@@ -20,17 +22,19 @@ type turingMachine struct {
 // Errors when the accept and reject states are the same state.
 func MakeTuringMachine(trans [][]string, start string, accept string, reject string) (machine.Machine, error) {
 	if accept == reject {
-		return turingMachine{}, fmt.Errorf("%s cannot be both the accept state and the reject state.", accept)
+		return turingMachine{}, machine.FieldError{Field: "reject", Err: fmt.Errorf("%s cannot be both the accept state and the reject state.", accept)}
 	}
 	transitions := []transition{}
-	for _, tran := range trans {
+	reads := [][2]string{}
+	for i, tran := range trans {
 		t, err := makeTransition(tran)
 		if err != nil {
-			return nil, err
+			return nil, machine.TransitionError{Index: i, Err: err}
 		}
 		transitions = append(transitions, t)
+		reads = append(reads, [2]string{t.in.state, t.in.symbol})
 	}
-	return turingMachine{transitions, start, accept, reject}, nil
+	return turingMachine{transitions, turing.MakeIndex(reads), start, accept, reject}, nil
 }
 
 // Start builds the first Config given a space-delimited input string.
@@ -85,37 +89,23 @@ func (tm turingMachine) IsReject(conf machine.Configuration) bool {
 	return conf.IsState(tm.reject)
 }
 
+// findTransition returns the state, symbol, and move of the first transition matching the state and symbol.
 func (tm turingMachine) findTransition(state string, symbol string) (string, string, string, error) {
-	for _, trans := range tm.trans {
-		in := trans.GetInput()
-		if len(in) != 2 {
-			return "", "", "", errors.New("Illegal transition.")
-		}
-		inState, inSymbol := in[0], in[1]
-		out := trans.GetOutput()
-		if len(out) != 3 {
-			return "", "", "", errors.New("Illegal transition.")
-		}
-		outState, outSymbol, outMove := out[0], out[1], out[2]
-		if (inState == state) || (inState == machine.Wildcard) {
-			if (inSymbol == symbol) || (inSymbol == machine.Wildcard) {
-				var next_symbol string
-				var next_state string
-				if outSymbol == machine.Wildcard {
-					next_symbol = symbol // if the output symbol is a wildcard, then re-write the symbol that is on the tape
-				} else {
-					next_symbol = outSymbol
-				}
-				if outState == machine.Wildcard {
-					next_state = state
-				} else {
-					next_state = outState
-				}
-				return next_state, next_symbol, outMove, nil
-			}
-		}
+	i := tm.index.First(state, symbol)
+	if i == -1 {
+		// no transition found
+		err := fmt.Errorf("no transition found for state: \"%s\" and symbol: \"%s\"", state, symbol)
+		return "", "", "", err
+	}
+	trans := tm.trans[i]
+
+	next_state := trans.out.state
+	if next_state == machine.Wildcard {
+		next_state = state
+	}
+	next_symbol := trans.out.symbol
+	if next_symbol == machine.Wildcard {
+		next_symbol = symbol // if the output symbol is a wildcard, then re-write the symbol that is on the tape
 	}
-	// no transition found
-	err := fmt.Errorf("no transition found for state: \"%s\" and symbol: \"%s\"", state, symbol)
-	return "", "", "", err
+	return next_state, next_symbol, trans.out.move, nil
 }
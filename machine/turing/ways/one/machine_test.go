@@ -2,6 +2,7 @@ package one_test
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/cjcodell1/tint/machine"
@@ -206,6 +207,17 @@ var doNothingTM, _ = one.MakeTuringMachine(
 	"accept",
 	"reject")
 
+var stayTM, _ = one.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", "q1", "b", turing.Stay},
+		{"q1", "b", "q2", "c", turing.NoMove},
+		{"q2", "c", "q2", "d", turing.Right},
+		{"q2", turing.Blank, "accept", turing.Blank, turing.Stay},
+	},
+	"q0",
+	"accept",
+	"reject")
+
 // set up the makeTuringMachineTests automatically
 func init() {
 	makeTuringMachineTests = []makeTuringMachine{
@@ -344,6 +356,18 @@ func init() {
 		{doNothingTM, "doNothingTM", step2, "{start [hello world !!!] 3}"},
 		{doNothingTM, "doNothingTM", step3, "{start [hello world !!! _] 4}"},
 	}...)
+
+	// adding the tests for stayTM
+	start = stayTM.Start("a")
+	step1, _ = stayTM.Step(start)
+	step2, _ = stayTM.Step(step1)
+	step3, _ = stayTM.Step(step2)
+	stepTests = append(stepTests, []step{
+		{stayTM, "stayTM", start, "{q1 [b] 0}"},
+		{stayTM, "stayTM", step1, "{q2 [c] 0}"},
+		{stayTM, "stayTM", step2, "{q2 [d] 1}"},
+		{stayTM, "stayTM", step3, "{accept [d _] 1}"},
+	}...)
 }
 
 // set up the isAcceptTests automatically
@@ -414,3 +438,25 @@ func init() {
 		{addMarkersTM, "addMarkersTM", step2, false},
 	}...)
 }
+
+// BenchmarkStep steps a Turing machine with 1000 states and 10 symbols, that counts its steps modulo 1000
+// in its state as it moves to the end of its tape, and then back and forth there forever.
+func BenchmarkStep(b *testing.B) {
+	trans := [][]string{}
+	for q := 0; q < 1000; q++ {
+		state := "q" + strconv.Itoa(q)
+		next := "q" + strconv.Itoa((q+1)%1000)
+		for s := 0; s < 10; s++ {
+			trans = append(trans, []string{state, strconv.Itoa(s), next, "*", turing.Right})
+		}
+		// turns around at the end of the tape
+		trans = append(trans, []string{state, "*", next, "*", turing.Left})
+	}
+	tm, _ := one.MakeTuringMachine(trans, "q0", "qa", "qr")
+
+	b.ResetTimer()
+	conf := tm.Start("9 8 7 6 5 4 3 2 1 0")
+	for i := 0; i < b.N; i++ {
+		conf, _ = tm.Step(conf)
+	}
+}
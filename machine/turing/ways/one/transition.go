@@ -2,6 +2,9 @@ package one
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/cjcodell1/tint/machine/turing"
 )
 
 // Transition represents a transition function.
@@ -25,7 +28,10 @@ type output struct {
 
 func makeTransition(inputs []string) (transition, error) {
 	if len(inputs) != 5 {
-		return transition{}, errors.New("Illegal Transition.")
+		return transition{}, fmt.Errorf("A transition must have 5 values, not %d.", len(inputs))
+	}
+	if err := turing.CheckMove(inputs[4]); err != nil {
+		return transition{}, err
 	}
 	return transition{input{inputs[0], inputs[1]}, output{inputs[2], inputs[3], inputs[4]}}, nil
 }
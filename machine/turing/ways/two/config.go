@@ -2,7 +2,6 @@ package two
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/cjcodell1/tint/machine"
@@ -11,7 +10,7 @@ import (
 
 type configuration struct {
 	state string
-	tape  []string
+	tape  *tape
 	head  int
 }
 
@@ -39,7 +38,7 @@ func (conf configuration) Print() string {
 
 	// now write what's on the tape
 	line1.WriteString(" ")
-	line1.WriteString(strings.Join(conf.tape, " "))
+	line1.WriteString(strings.Join(conf.tape.symbols(), " "))
 
 	carrot := 0
 	for {
@@ -48,7 +47,7 @@ func (conf configuration) Print() string {
 			break
 		} else {
 			line2.WriteString(" ")
-			for _, _ = range conf.tape[carrot] {
+			for _, _ = range conf.tape.read(carrot) {
 				line2.WriteString(" ")
 			}
 			carrot += 1
@@ -66,6 +65,16 @@ func (conf configuration) IsState(state string) bool {
 	return conf.state == state
 }
 
+// State returns the state.
+func (conf configuration) State() string {
+	return conf.state
+}
+
+// Tapes returns the tape and where the head is on it.
+func (conf configuration) Tapes() ([][]string, []int) {
+	return [][]string{conf.tape.symbols()}, []int{conf.head}
+}
+
 func (conf configuration) CanNext() bool {
 	return true
 }
@@ -75,60 +84,44 @@ func (conf configuration) Next(inputs []string) (machine.Configuration, error) {
 		return nil, errors.New("Illegal configuration.")
 	}
 
-	next_state := inputs[0]
-	next_symbol := inputs[1]
-	next_move := inputs[2]
-
 	// Assume that conf is not in an accept or a reject state.
 
 	// Don't want to mutate
-	prevTape := make([]string, len(conf.tape))
-	copy(prevTape, conf.tape)
+	next_conf := configuration{conf.state, conf.tape.copy(), conf.head}
+	if err := next_conf.step(inputs[0], inputs[1], inputs[2]); err != nil {
+		return configuration{}, err
+	}
+	return next_conf, nil
+}
 
-	var next_conf configuration
-	// transition to the next state
-	next_conf.state = next_state
+// step transitions to the next state, writes the next symbol, and moves the head, changing the tape in place.
+func (conf *configuration) step(next_state string, next_symbol string, next_move string) error {
+	if err := turing.CheckMove(next_move); err != nil {
+		return err
+	}
 
-	leng := len(prevTape)
-	head := conf.head
+	// transition to the next state
+	conf.state = next_state
 
 	// write the next symbol
-	if (head == leng) {
-		next_conf.tape = append(prevTape, next_symbol)
-	} else {
-		next_conf.tape = prevTape
-		next_conf.tape[conf.head] = next_symbol
-	}
+	conf.tape.write(conf.head, next_symbol)
 
 	// move in the next direction
-
-	// move AFTER write, so take another len with the next tape
-	leng = len(next_conf.tape)
-
-	if (head == 0) && (next_move == turing.Left) {
+	if (conf.head == 0) && (next_move == turing.Left) {
 		// the head remains 0 instead of -1 to avoid confusion of if the head is at the end of the tape:
 		// tape:  "_ _ a a a a _ a a a _" len=11
 		// start:     ^
 		// head:              ^           pos=4  how to check if head is at end of tape?
 		// head:                      ^   pos=8  how to check if head is at end of tape?
-		next_conf.tape = append([]string{turing.Blank}, next_conf.tape...)
-		next_conf.head = 0
-	} else {
-		if next_move == turing.Right {
-			next_conf.head = head + 1
-		} else if next_move == turing.Left {
-			next_conf.head = head - 1
-		} else {
-			return configuration{}, fmt.Errorf("%s is not a legal move, use %s or %s", next_move, turing.Right, turing.Left)
-		}
+		conf.tape.prepend(turing.Blank)
+	} else if next_move == turing.Right {
+		conf.head += 1
+	} else if next_move == turing.Left {
+		conf.head -= 1
 	}
-
-	return next_conf, nil
+	return nil
 }
 
 func (conf configuration) GetNext() ([]string, error) {
-	if conf.head < len(conf.tape) {
-		return []string{conf.state, conf.tape[conf.head]}, nil
-	}
-	return []string{conf.state, turing.Blank}, nil
+	return []string{conf.state, conf.tape.read(conf.head)}, nil
 }
@@ -6,10 +6,12 @@ import (
 	"strings"
 
 	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
 )
 
 type turingMachine struct {
 	trans  []transition
+	index  turing.Index // finds the transitions in trans by what they read
 	start  string
 	accept string
 	reject string
@@ -20,22 +22,24 @@ type turingMachine struct {
 // Errors when the accept and reject states are the same state.
 func MakeTuringMachine(trans [][]string, start string, accept string, reject string) (machine.Machine, error) {
 	if accept == reject {
-		return turingMachine{}, fmt.Errorf("%s cannot be both the accept state and the reject state.", accept)
+		return turingMachine{}, machine.FieldError{Field: "reject", Err: fmt.Errorf("%s cannot be both the accept state and the reject state.", accept)}
 	}
 	transitions := []transition{}
-	for _, tran := range trans {
+	reads := [][2]string{}
+	for i, tran := range trans {
 		t, err := makeTransition(tran)
 		if err != nil {
-			return nil, err
+			return nil, machine.TransitionError{Index: i, Err: err}
 		}
 		transitions = append(transitions, t)
+		reads = append(reads, [2]string{t.in.state, t.in.symbol})
 	}
-	return turingMachine{transitions, start, accept, reject}, nil
+	return turingMachine{transitions, turing.MakeIndex(reads), start, accept, reject}, nil
 }
 
 // Start builds the first Config given a space-delimited input string.
 func (tm turingMachine) Start(input string) machine.Configuration {
-	return configuration{tm.start, strings.Fields(input), 0}
+	return configuration{tm.start, newTape(strings.Fields(input)), 0}
 }
 
 // Step applies one transition to the given Config.
@@ -71,6 +75,40 @@ func (tm turingMachine) Step(conf machine.Configuration) (machine.Configuration,
 	return next_conf, nil
 }
 
+// StepInPlace is the same as Step, but writes to the tape of the Config instead of copying it,
+// so it takes amortized constant time. The Config must not be used after, so use Snapshot to keep a copy of it.
+func (tm turingMachine) StepInPlace(conf machine.Configuration) (machine.Configuration, error) {
+
+	// if the state is accept or reject, then don't do anything
+	if tm.IsAccept(conf) || tm.IsReject(conf) {
+		return conf, nil
+	}
+
+	twoWay, ok := conf.(configuration)
+	if !ok {
+		return nil, errors.New("Illegal configuration.")
+	}
+
+	next_state, next_symbol, next_move, err := tm.findTransition(twoWay.state, twoWay.tape.read(twoWay.head))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := twoWay.step(next_state, next_symbol, next_move); err != nil {
+		return nil, err
+	}
+	return twoWay, nil
+}
+
+// Snapshot returns a copy of the Config that stepping it in place does not change.
+func (tm turingMachine) Snapshot(conf machine.Configuration) machine.Configuration {
+	twoWay, ok := conf.(configuration)
+	if !ok {
+		return conf
+	}
+	return configuration{twoWay.state, twoWay.tape.copy(), twoWay.head}
+}
+
 // IsAccept returns true if the Config is in an accept state.
 func (tm turingMachine) IsAccept(conf machine.Configuration) bool {
 	twoWay, ok := conf.(configuration)
@@ -85,37 +123,23 @@ func (tm turingMachine) IsReject(conf machine.Configuration) bool {
 	return conf.IsState(tm.reject)
 }
 
+// findTransition returns the state, symbol, and move of the first transition matching the state and symbol.
 func (tm turingMachine) findTransition(state string, symbol string) (string, string, string, error) {
-	for _, trans := range tm.trans {
-		in := trans.GetInput()
-		if len(in) != 2 {
-			return "", "", "", errors.New("Illegal transition.")
-		}
-		inState, inSymbol := in[0], in[1]
-		out := trans.GetOutput()
-		if len(out) != 3 {
-			return "", "", "", errors.New("Illegal transition.")
-		}
-		outState, outSymbol, outMove := out[0], out[1], out[2]
-		if (inState == state) || (inState == machine.Wildcard) {
-			if (inSymbol == symbol) || (inSymbol == machine.Wildcard) {
-				var next_symbol string
-				var next_state string
-				if outSymbol == machine.Wildcard {
-					next_symbol = symbol // if the output symbol is a wildcard, then re-write the symbol that is on the tape
-				} else {
-					next_symbol = outSymbol
-				}
-				if outState == machine.Wildcard {
-					next_state = state
-				} else {
-					next_state = outState
-				}
-				return next_state, next_symbol, outMove, nil
-			}
-		}
+	i := tm.index.First(state, symbol)
+	if i == -1 {
+		// no transition found
+		err := fmt.Errorf("no transition found for state: \"%s\" and symbol: \"%s\"", state, symbol)
+		return "", "", "", err
+	}
+	trans := tm.trans[i]
+
+	next_state := trans.out.state
+	if next_state == machine.Wildcard {
+		next_state = state
+	}
+	next_symbol := trans.out.symbol
+	if next_symbol == machine.Wildcard {
+		next_symbol = symbol // if the output symbol is a wildcard, then re-write the symbol that is on the tape
 	}
-	// no transition found
-	err := fmt.Errorf("no transition found for state: \"%s\" and symbol: \"%s\"", state, symbol)
-	return "", "", "", err
+	return next_state, next_symbol, trans.out.move, nil
 }
@@ -75,9 +75,19 @@ func TestNewTuringMachine(t *testing.T) {
 	}
 }
 
+// show writes a configuration as {state [tape] head}.
+func show(conf machine.Configuration) string {
+	tc, ok := conf.(machine.TapeConfiguration)
+	if !ok {
+		return fmt.Sprint(conf)
+	}
+	tapes, heads := tc.Tapes()
+	return fmt.Sprintf("{%s %v %d}", tc.State(), tapes[0], heads[0])
+}
+
 func TestStart(t *testing.T) {
 	for _, tc := range startTests {
-		got := fmt.Sprint(tc.tm.Start(tc.input))
+		got := show(tc.tm.Start(tc.input))
 		if got != tc.expect {
 			t.Errorf("%s.Start(%s) == %v != %s", tc.tmName, tc.input, got, tc.expect)
 		}
@@ -86,14 +96,57 @@ func TestStart(t *testing.T) {
 
 func TestStep(t *testing.T) {
 	for _, tc := range stepTests {
+		before := show(tc.input)
 		gotMachine, _ := tc.tm.Step(tc.input)
-		got := fmt.Sprint(gotMachine)
+		got := show(gotMachine)
 		if got != tc.expect {
-			t.Errorf("%s.Step(%v) == %v != %s", tc.tmName, tc.input, got, tc.expect)
+			t.Errorf("%s.Step(%s) == %s != %s", tc.tmName, before, got, tc.expect)
+		}
+		if after := show(tc.input); after != before {
+			t.Errorf("%s.Step(%s) changed the configuration to %s", tc.tmName, before, after)
+		}
+	}
+}
+
+func TestStepInPlace(t *testing.T) {
+	for _, tc := range stepTests {
+		tm := tc.tm.(machine.InPlaceStepper)
+		before := show(tc.input)
+		snapshot := tm.Snapshot(tc.input)
+		gotMachine, _ := tm.StepInPlace(tm.Snapshot(tc.input))
+		got := show(gotMachine)
+		if got != tc.expect {
+			t.Errorf("%s.StepInPlace(%s) == %s != %s", tc.tmName, before, got, tc.expect)
+		}
+		if after := show(snapshot); after != before {
+			t.Errorf("%s.StepInPlace(%s) changed a snapshot to %s", tc.tmName, before, after)
 		}
 	}
 }
 
+// TestStepInPlaceLeft steps in place far past the start of the tape, where the tape has to grow.
+func TestStepInPlaceLeft(t *testing.T) {
+	tm := leftTM.(machine.InPlaceStepper)
+	conf := tm.Start("a b")
+	var snapshots []machine.Configuration
+	for i := 0; i < 100; i++ {
+		snapshots = append(snapshots, tm.Snapshot(conf))
+		conf, _ = tm.StepInPlace(conf)
+	}
+
+	// every snapshot is the same as stepping without writing in place
+	expect := leftTM.Start("a b")
+	for i, snapshot := range snapshots {
+		if show(snapshot) != show(expect) {
+			t.Fatalf("leftTM snapshot after %d steps == %s != %s", i, show(snapshot), show(expect))
+		}
+		expect, _ = leftTM.Step(expect)
+	}
+	if show(conf) != show(expect) {
+		t.Errorf("leftTM.StepInPlace after 100 steps == %s != %s", show(conf), show(expect))
+	}
+}
+
 func TestIsAccept(t *testing.T) {
 	for _, tc := range isAcceptTests {
 		got := tc.tm.IsAccept(tc.input)
@@ -113,6 +166,14 @@ func TestIsReject(t *testing.T) {
 }
 
 // Turing machines to test
+var leftTM, _ = two.MakeTuringMachine(
+	[][]string{
+		{"start", "*", "start", "x", turing.Left},
+	},
+	"start",
+	"accept",
+	"reject")
+
 var emptyTM, _ = two.MakeTuringMachine(
 	[][]string{
 		{"start", "a", "reject", "c", turing.Right},
@@ -206,6 +267,17 @@ var doNothingTM, _ = two.MakeTuringMachine(
 	"accept",
 	"reject")
 
+var stayTM, _ = two.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", "q1", "b", turing.Stay},
+		{"q1", "b", "q2", "c", turing.NoMove},
+		{"q2", "c", "q2", "d", turing.Right},
+		{"q2", turing.Blank, "accept", turing.Blank, turing.Stay},
+	},
+	"q0",
+	"accept",
+	"reject")
+
 // set up the makeTuringMachineTests automatically
 func init() {
 	makeTuringMachineTests = []makeTuringMachine{
@@ -340,6 +412,18 @@ func init() {
 		{doNothingTM, "doNothingTM", step2, "{start [hello world !!!] 3}"},
 		{doNothingTM, "doNothingTM", step3, "{start [hello world !!! _] 4}"},
 	}...)
+
+	// adding the tests for stayTM
+	start = stayTM.Start("a")
+	step1, _ = stayTM.Step(start)
+	step2, _ = stayTM.Step(step1)
+	step3, _ = stayTM.Step(step2)
+	stepTests = append(stepTests, []step{
+		{stayTM, "stayTM", start, "{q1 [b] 0}"},
+		{stayTM, "stayTM", step1, "{q2 [c] 0}"},
+		{stayTM, "stayTM", step2, "{q2 [d] 1}"},
+		{stayTM, "stayTM", step3, "{accept [d _] 1}"},
+	}...)
 }
 
 // set up the isAcceptTests automatically
@@ -410,3 +494,30 @@ func init() {
 		{addMarkersTM, "addMarkersTM", step2, false},
 	}...)
 }
+
+// sweepTM sweeps back and forth across its tape forever, making it one cell longer at each end each time.
+var sweepTM, _ = two.MakeTuringMachine(
+	[][]string{
+		{"right", "a", "right", "a", turing.Right},
+		{"right", turing.Blank, "left", "a", turing.Left},
+		{"left", "a", "left", "a", turing.Left},
+		{"left", turing.Blank, "right", "a", turing.Right},
+	},
+	"right",
+	"accept",
+	"reject")
+
+func BenchmarkStep(b *testing.B) {
+	conf := sweepTM.Start("a")
+	for i := 0; i < b.N; i++ {
+		conf, _ = sweepTM.Step(conf)
+	}
+}
+
+func BenchmarkStepInPlace(b *testing.B) {
+	tm := sweepTM.(machine.InPlaceStepper)
+	conf := tm.Start("a")
+	for i := 0; i < b.N; i++ {
+		conf, _ = tm.StepInPlace(conf)
+	}
+}
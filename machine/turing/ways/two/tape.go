@@ -0,0 +1,61 @@
+package two
+
+import "github.com/cjcodell1/tint/machine/turing"
+
+// tape holds the cells of a two-way infinite tape in a buffer with room to grow at both ends,
+// so writing past either end of the tape takes amortized constant time.
+type tape struct {
+	cells []string // the tape is cells[start:], the cells before it are unused
+	start int
+}
+
+func newTape(symbols []string) *tape {
+	return &tape{cells: symbols}
+}
+
+func (t *tape) len() int {
+	return len(t.cells) - t.start
+}
+
+// symbols returns the cells of the tape, which change when the tape is written to.
+func (t *tape) symbols() []string {
+	return t.cells[t.start:]
+}
+
+// read returns the symbol in cell i, or a blank if i is past the end of the tape.
+func (t *tape) read(i int) string {
+	if i < t.len() {
+		return t.cells[t.start+i]
+	}
+	return turing.Blank
+}
+
+// write writes the symbol to cell i, which can be one past the end of the tape.
+func (t *tape) write(i int, symbol string) {
+	if i == t.len() {
+		t.cells = append(t.cells, symbol)
+	} else {
+		t.cells[t.start+i] = symbol
+	}
+}
+
+// prepend adds a cell with the symbol before the start of the tape.
+func (t *tape) prepend(symbol string) {
+	if t.start == 0 {
+		// double the room before the tape, like append does after it
+		room := t.len() + 1
+		cells := make([]string, room+len(t.cells))
+		copy(cells[room:], t.cells)
+		t.cells = cells
+		t.start = room
+	}
+	t.start--
+	t.cells[t.start] = symbol
+}
+
+// copy returns a tape with the same cells, that does not change when this one is written to.
+func (t *tape) copy() *tape {
+	cells := make([]string, t.len())
+	copy(cells, t.symbols())
+	return newTape(cells)
+}
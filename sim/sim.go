@@ -0,0 +1,150 @@
+// Package sim simulates machines on inputs until they halt, for programs that use tint as a library.
+package sim
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cjcodell1/tint/machine"
+)
+
+// The outcomes of a simulation.
+// Accept and Reject are the same as file.Accept and file.Reject, so they can be compared with what a test expects.
+const (
+	Accept   = "accept"
+	Reject   = "reject"
+	Error    = "error"    // the machine could not take a step
+	Timeout  = "timeout"  // the machine did not halt within the step limit, or before the context's deadline
+	Loop     = "loop"     // the machine repeated a configuration, so it will never halt
+	Canceled = "canceled" // the context was canceled
+)
+
+// Options change how a machine is simulated. The zero value simulates until the machine halts.
+type Options struct {
+	// The most steps to take, 0 for no limit.
+	MaxSteps int
+	// Stops the simulation when a configuration repeats, which means the machine will never halt.
+	// Configurations with tapes are compared by their state, heads, and the lengths of their tapes,
+	// and by what they print only when those are the same. Other configurations never repeat,
+	// since NFAs read a symbol every step and PDAs and nondeterministic Turing machines drop the branches
+	// they found before, so they are not compared.
+	DetectLoops bool
+	// Called with each configuration as it is reached, from the start configuration (step 0) to the last.
+	// The configurations are never changed after, so they can be kept.
+	Trace func(step int, conf machine.Configuration)
+}
+
+// Result is what happened when a machine was simulated.
+type Result struct {
+	Outcome  string                // one of the outcomes above
+	Steps    int                   // the number of steps taken
+	Final    machine.Configuration // the configuration the simulation stopped in
+	Err      error                 // the error of the machine when the Outcome is Error, or of the context when it is Canceled
+	LoopFrom int                   // the step whose configuration repeats when the Outcome is Loop
+	Elapsed  time.Duration         // how long the simulation took
+}
+
+// Run simulates the machine on a space-delimited input until it accepts, rejects, errors,
+// or is stopped by the options or the context.
+// A machine.InPlaceStepper is stepped in place, and only copied for the trace.
+func Run(ctx context.Context, m machine.Machine, input string, opts Options) (res Result) {
+	var err error
+	stepper, inPlace := m.(machine.InPlaceStepper)
+	begin := time.Now()
+	defer func() {
+		res.Elapsed = time.Since(begin)
+	}()
+
+	// Brent's cycle detection: remember a configuration, and check every later one against it,
+	// remembering a new one each time the number of steps since doubles.
+	saved := ""    // what the remembered configuration prints
+	savedKey := "" // the key of the remembered configuration
+	savedStep := 0
+	power := 1
+
+	conf := m.Start(input)
+	_, tapes := conf.(machine.TapeConfiguration)
+	detectLoops := opts.DetectLoops && tapes
+	for steps := 0; ; steps++ {
+		if opts.Trace != nil {
+			if inPlace {
+				opts.Trace(steps, stepper.Snapshot(conf))
+			} else {
+				opts.Trace(steps, conf)
+			}
+		}
+		res.Steps = steps
+		res.Final = conf
+
+		// check if accept or reject and break
+		if m.IsAccept(conf) {
+			res.Outcome = Accept
+			return res
+		} else if m.IsReject(conf) {
+			res.Outcome = Reject
+			return res
+		}
+
+		// check if the machine is stuck in a loop
+		if detectLoops {
+			// only prints the configuration when it could be the remembered one
+			current := key(conf.(machine.TapeConfiguration))
+			if steps != 0 && current == savedKey && conf.Print() == saved {
+				res.Outcome = Loop
+				res.LoopFrom = savedStep
+				return res
+			}
+			if steps-savedStep == power || steps == 0 {
+				saved = conf.Print()
+				savedKey = current
+				savedStep = steps
+				power *= 2
+			}
+		}
+
+		// check if the machine ran for too long
+		if opts.MaxSteps > 0 && steps >= opts.MaxSteps {
+			res.Outcome = Timeout
+			return res
+		}
+		select {
+		case <-ctx.Done():
+			res.Outcome = Timeout
+			if ctx.Err() == context.Canceled {
+				res.Outcome = Canceled
+				res.Err = ctx.Err()
+			}
+			return res
+		default:
+		}
+
+		// step
+		if inPlace {
+			conf, err = stepper.StepInPlace(conf)
+		} else {
+			conf, err = m.Step(conf)
+		}
+		if err != nil {
+			res.Outcome = Error
+			res.Err = err
+			return res
+		}
+	}
+}
+
+// key summarizes a configuration with tapes, so configurations with different keys are different.
+// The key is its state, heads, and the lengths of its tapes, which takes constant time however long the tapes are.
+func key(tc machine.TapeConfiguration) string {
+	var k strings.Builder
+	k.WriteString(tc.State())
+	tapes, heads := tc.Tapes()
+	for i := range tapes {
+		k.WriteString(" ")
+		k.WriteString(strconv.Itoa(heads[i]))
+		k.WriteString("/")
+		k.WriteString(strconv.Itoa(len(tapes[i])))
+	}
+	return k.String()
+}
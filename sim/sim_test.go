@@ -0,0 +1,168 @@
+package sim_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+	"github.com/cjcodell1/tint/machine/turing/ways/one"
+	"github.com/cjcodell1/tint/machine/turing/ways/two"
+	"github.com/cjcodell1/tint/sim"
+)
+
+// evenAs accepts the strings over {a, b} with an even number of a's.
+var evenAs machine.Machine
+
+// right moves right forever, and stay stays where it is forever.
+var right, stay machine.Machine
+
+// flip writes b, then c, over the a it starts on without moving, then accepts.
+var flip machine.Machine
+
+// left is a two-way Turing machine that writes an a and moves left forever, and is stepped in place.
+var left machine.Machine
+
+func init() {
+	evenAs, _ = dfa.MakeDFA([][]string{
+		{"even", "a", "odd"},
+		{"even", "b", "even"},
+		{"odd", "a", "even"},
+		{"odd", "b", "odd"},
+	}, "even", []string{"even"})
+	right, _ = one.MakeTuringMachine([][]string{{"q0", "*", "q0", "*", "R"}}, "q0", "qa", "qr")
+	stay, _ = one.MakeTuringMachine([][]string{{"q0", "*", "q0", "*", "S"}}, "q0", "qa", "qr")
+	flip, _ = one.MakeTuringMachine([][]string{
+		{"q0", "a", "q0", "b", "S"},
+		{"q0", "b", "q0", "c", "S"},
+		{"q0", "c", "qa", "c", "S"},
+	}, "q0", "qa", "qr")
+	left, _ = two.MakeTuringMachine([][]string{{"go", "*", "go", "a", "L"}}, "go", "qa", "qr")
+}
+
+type runTest struct {
+	m       machine.Machine
+	name    string
+	input   string
+	opts    sim.Options
+	outcome string
+	steps   int
+}
+
+var runTests []runTest
+
+func init() {
+	runTests = []runTest{
+		{evenAs, "evenAs", "a b a", sim.Options{}, sim.Accept, 3},
+		{evenAs, "evenAs", "a b", sim.Options{}, sim.Reject, 2},
+		{evenAs, "evenAs", "", sim.Options{}, sim.Accept, 0},
+		{evenAs, "evenAs", "a c", sim.Options{}, sim.Error, 1},
+		{right, "right", "a", sim.Options{MaxSteps: 100}, sim.Timeout, 100},
+		{right, "right", "a", sim.Options{MaxSteps: 100, DetectLoops: true}, sim.Timeout, 100},
+		{stay, "stay", "a", sim.Options{DetectLoops: true}, sim.Loop, 1},
+		{flip, "flip", "a", sim.Options{DetectLoops: true}, sim.Accept, 3},
+		{left, "left", "b", sim.Options{MaxSteps: 100, DetectLoops: true}, sim.Timeout, 100},
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range runTests {
+		res := sim.Run(context.Background(), tc.m, tc.input, tc.opts)
+		if res.Outcome != tc.outcome || res.Steps != tc.steps {
+			t.Errorf("Run(%s, %q, %+v) == %s after %d steps != %s after %d steps", tc.name, tc.input, tc.opts, res.Outcome, res.Steps, tc.outcome, tc.steps)
+		}
+		if (res.Outcome == sim.Error) != (res.Err != nil) {
+			t.Errorf("Run(%s, %q, %+v).Err == %v", tc.name, tc.input, tc.opts, res.Err)
+		}
+	}
+}
+
+// TestRunTrace checks the trace is called with every configuration, in order.
+func TestRunTrace(t *testing.T) {
+	var trace []string
+	res := sim.Run(context.Background(), evenAs, "a b a", sim.Options{Trace: func(step int, conf machine.Configuration) {
+		if step != len(trace) {
+			t.Errorf("Trace(%d, %s) after %d configurations", step, conf.Print(), len(trace))
+		}
+		trace = append(trace, conf.Print())
+	}})
+
+	expect := []string{"even: a b a", "odd: b a", "odd: a", "even: "}
+	if len(trace) != len(expect) {
+		t.Fatalf("Run(evenAs, \"a b a\") traced %q != %q", trace, expect)
+	}
+	for i := range expect {
+		if trace[i] != expect[i] {
+			t.Errorf("Run(evenAs, \"a b a\") traced %q != %q", trace, expect)
+			break
+		}
+	}
+	if res.Final.Print() != expect[len(expect)-1] {
+		t.Errorf("Run(evenAs, \"a b a\").Final == %s != %s", res.Final.Print(), expect[len(expect)-1])
+	}
+}
+
+// TestRunInPlace checks a machine stepped in place ends the same with and without a trace,
+// and that the configurations in the trace do not change as it keeps stepping.
+func TestRunInPlace(t *testing.T) {
+	if _, ok := left.(machine.InPlaceStepper); !ok {
+		t.Fatal("left is not stepped in place")
+	}
+
+	var trace []machine.Configuration
+	var printed []string
+	traced := sim.Run(context.Background(), left, "b", sim.Options{MaxSteps: 10, Trace: func(step int, conf machine.Configuration) {
+		trace = append(trace, conf)
+		printed = append(printed, conf.Print())
+	}})
+	untraced := sim.Run(context.Background(), left, "b", sim.Options{MaxSteps: 10})
+
+	if traced.Final.Print() != untraced.Final.Print() || traced.Steps != untraced.Steps {
+		t.Errorf("Run(left, \"b\") == %q after %d steps with a trace != %q after %d steps without", traced.Final.Print(), traced.Steps, untraced.Final.Print(), untraced.Steps)
+	}
+	if len(trace) != 11 {
+		t.Fatalf("Run(left, \"b\") traced %d configurations != 11", len(trace))
+	}
+	for i, conf := range trace {
+		if conf.Print() != printed[i] {
+			t.Errorf("Run(left, \"b\") changed the configuration of step %d from %q to %q", i, printed[i], conf.Print())
+		}
+	}
+	if trace[10].Print() != traced.Final.Print() {
+		t.Errorf("Run(left, \"b\") traced %q last != %q", trace[10].Print(), traced.Final.Print())
+	}
+}
+
+// TestRunContext checks a machine that never halts is stopped by the context.
+func TestRunContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	res := sim.Run(ctx, right, "a", sim.Options{})
+	if res.Outcome != sim.Timeout {
+		t.Errorf("Run(right, \"a\") with a deadline == %s != %s", res.Outcome, sim.Timeout)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	res = sim.Run(ctx, right, "a", sim.Options{})
+	if res.Outcome != sim.Canceled || res.Err != context.Canceled || res.Steps != 0 {
+		t.Errorf("Run(right, \"a\") canceled == %s, %v after %d steps != %s, %v after 0 steps", res.Outcome, res.Err, res.Steps, sim.Canceled, context.Canceled)
+	}
+}
+
+// BenchmarkRunInPlace runs a machine stepped in place, whose tape grows every step, with loop detection as the CLI does.
+func BenchmarkRunInPlace(b *testing.B) {
+	sim.Run(context.Background(), left, "b", sim.Options{MaxSteps: b.N, DetectLoops: true})
+}
+
+// BenchmarkRunWithoutTapes runs an NFA, whose configuration has no tapes, on an input of b.N symbols with loop detection,
+// which costs nothing since its configurations are not compared.
+func BenchmarkRunWithoutTapes(b *testing.B) {
+	anyAs, _ := nfa.MakeNFA([][]string{{"q0", "a", "q0"}, {"q0", "a", "q1"}}, "q0", []string{"q0"})
+	input := strings.TrimSpace(strings.Repeat("a ", b.N))
+	b.ResetTimer()
+	sim.Run(context.Background(), anyAs, input, sim.Options{DetectLoops: true})
+}
@@ -0,0 +1,272 @@
+// Package validate finds mistakes in machines without simulating them.
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/turing"
+)
+
+// The severities of a Problem.
+const (
+	Error   string = "error"   // the machine does something it almost certainly should not
+	Warning string = "warning" // the machine might do something it should not
+)
+
+// Problem is a mistake found in a machine.
+type Problem struct {
+	Severity   string
+	Transition int // the index of the transition the problem is with, or -1 if it is with the machine
+	Message    string
+}
+
+// String writes the problem, with the transition counted from 1 like the lines of a list.
+func (p Problem) String() string {
+	if p.Transition < 0 {
+		return fmt.Sprintf("%s: %s", p.Severity, p.Message)
+	}
+	return fmt.Sprintf("%s: transition %d: %s", p.Severity, p.Transition+1, p.Message)
+}
+
+// layout is where the parts of a transition are for a type of machine.
+type layout struct {
+	input         int  // the length of the input, the state and what is read, at the start of a transition
+	to            int  // the index of the state the transition goes to
+	deterministic bool // only the first matching transition is followed
+	wildcards     bool // "*" matches any state or symbol
+	halts         bool // the machine halts in its accept and reject states, and errors anywhere else without a transition
+}
+
+// layoutOf returns the layout of the transitions of a machine.
+func layoutOf(d machine.Describer) (layout, error) {
+	switch d.Type() {
+	case machine.DFA:
+		return layout{2, 2, true, false, false}, nil
+	case machine.NFA:
+		return layout{2, 2, false, false, false}, nil
+	case machine.PDA:
+		return layout{3, 3, false, false, false}, nil
+	case machine.ONE_WAY_TM, machine.TWO_WAY_TM:
+		return layout{2, 2, true, true, true}, nil
+	case machine.NTM:
+		return layout{2, 2, false, true, true}, nil
+	case machine.MULTI_TAPE_TM:
+		// [state, symbol..., state, symbol..., move...] has a symbol, symbol and move for each tape
+		trans := d.Transitions()
+		tapes := 1
+		if len(trans) > 0 {
+			tapes = (len(trans[0]) - 2) / 3
+		}
+		return layout{1 + tapes, 1 + tapes, true, true, true}, nil
+	default:
+		return layout{}, fmt.Errorf("A %s cannot be validated.", d.Type())
+	}
+}
+
+// Validate runs every check for the type of machine, and returns the problems found,
+// first the problems with the machine and then the problems with each transition in order.
+func Validate(d machine.Describer) ([]Problem, error) {
+	l, err := layoutOf(d)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator{d: d, l: l, trans: d.Transitions()}
+	v.checkStates()
+	v.checkMissing()
+	v.checkTransitions()
+	return v.sorted(), nil
+}
+
+type validator struct {
+	d        machine.Describer
+	l        layout
+	trans    [][]string
+	reached  map[string]bool // the states reachable from the start state
+	problems []Problem
+}
+
+func (v *validator) add(severity string, transition int, format string, a ...interface{}) {
+	v.problems = append(v.problems, Problem{severity, transition, fmt.Sprintf(format, a...)})
+}
+
+// sorted orders the problems with the machine first, then by transition, keeping the order they were found in.
+func (v *validator) sorted() []Problem {
+	sorted := []Problem{}
+	for i := -1; i < len(v.trans); i++ {
+		for _, p := range v.problems {
+			if p.Transition == i {
+				sorted = append(sorted, p)
+			}
+		}
+	}
+	return sorted
+}
+
+// halting is true for the accept and reject states of a machine that halts in them.
+func (v *validator) halting(state string) bool {
+	if !v.l.halts {
+		return false
+	}
+	for _, s := range append(v.d.AcceptStates(), v.d.RejectStates()...) {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// from is true if a transition can be followed from the state.
+func (v *validator) from(t []string, state string) bool {
+	return t[0] == state || (v.l.wildcards && t[0] == machine.Wildcard && !v.halting(state))
+}
+
+// checkStates checks that every state can be reached from the start state,
+// that the accept states are in a transition, and that a Turing machine does not stop outside of its accept and reject states.
+func (v *validator) checkStates() {
+	v.reached = map[string]bool{v.d.StartState(): true}
+	reached := v.reached
+	queue := []string{v.d.StartState()}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		if v.halting(state) {
+			continue
+		}
+		for _, t := range v.trans {
+			if !v.from(t, state) {
+				continue
+			}
+			to := t[v.l.to]
+			if v.l.wildcards && to == machine.Wildcard {
+				to = state
+			}
+			if !reached[to] {
+				reached[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, t := range v.trans {
+		used[t[0]] = true
+		used[t[v.l.to]] = true
+	}
+	for _, state := range v.d.AcceptStates() {
+		if !used[state] && state != v.d.StartState() {
+			v.add(Warning, -1, "the accept state %s is not in any transition, it may be misspelled", state)
+		}
+	}
+
+	for _, state := range v.d.States() {
+		switch {
+		case !reached[state]:
+			v.add(Warning, -1, "the state %s cannot be reached from the start state", state)
+		case v.l.halts && !v.halting(state) && !v.hasOut(state):
+			v.add(Error, -1, "the state %s has no transitions, so the machine errors when it gets there (it may be misspelled)", state)
+		}
+	}
+}
+
+// hasOut is true if any transition can be followed from the state.
+func (v *validator) hasOut(state string) bool {
+	for _, t := range v.trans {
+		if v.from(t, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMissing checks that a deterministic single-tape machine has a transition for every reachable state and symbol it can read.
+// The symbols are those read by a DFA, and those read or written by a Turing machine, along with the blank.
+func (v *validator) checkMissing() {
+	if !v.l.deterministic || v.l.input != 2 {
+		return
+	}
+
+	symbols := []string{}
+	seen := make(map[string]bool)
+	addSymbol := func(symbol string) {
+		if !seen[symbol] && symbol != machine.Wildcard {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	for _, t := range v.trans {
+		addSymbol(t[1])
+		if v.l.halts {
+			addSymbol(t[3])
+		}
+	}
+	if v.l.halts {
+		addSymbol(turing.Blank)
+	}
+
+	for _, state := range v.d.States() {
+		if !v.reached[state] || v.halting(state) || (v.l.halts && !v.hasOut(state)) {
+			continue // already a problem if it cannot be reached or left
+		}
+		missing := []string{}
+		for _, symbol := range symbols {
+			found := false
+			for _, t := range v.trans {
+				if v.from(t, state) && (t[1] == symbol || (v.l.wildcards && t[1] == machine.Wildcard)) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, symbol)
+			}
+		}
+		if len(missing) > 0 {
+			v.add(Warning, -1, "the state %s has no transition for %s, so the machine errors if it reads it there", state, strings.Join(missing, ", "))
+		}
+	}
+}
+
+// checkTransitions checks each transition against the transitions before it,
+// and that a Turing machine has no transitions out of its accept and reject states.
+func (v *validator) checkTransitions() {
+	for j, t := range v.trans {
+		if v.halting(t[0]) {
+			v.add(Warning, j, "%s halts the machine, so this transition is never followed", t[0])
+		}
+
+		for i, earlier := range v.trans[:j] {
+			if strings.Join(earlier, "\x00") == strings.Join(t, "\x00") {
+				v.add(Warning, j, "it is the same as transition %d", i+1)
+				break
+			}
+			if !v.l.deterministic {
+				continue
+			}
+			if same(earlier[:v.l.input], t[:v.l.input]) {
+				v.add(Error, j, "it reads the same as transition %d, which is followed instead, so this transition is never followed", i+1)
+				break
+			}
+			if v.l.wildcards && covers(earlier[:v.l.input], t[:v.l.input]) {
+				v.add(Error, j, "the wildcard in transition %d matches everything this transition does, so this transition is never followed", i+1)
+				break
+			}
+		}
+	}
+}
+
+func same(a []string, b []string) bool {
+	return strings.Join(a, "\x00") == strings.Join(b, "\x00")
+}
+
+// covers is true if every input the second matches is matched by the first.
+func covers(first []string, second []string) bool {
+	for i := range first {
+		if first[i] != machine.Wildcard && first[i] != second[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cjcodell1/tint/machine"
+	"github.com/cjcodell1/tint/machine/finite/dfa"
+	"github.com/cjcodell1/tint/machine/finite/nfa"
+	"github.com/cjcodell1/tint/machine/turing"
+	"github.com/cjcodell1/tint/machine/turing/multi"
+	"github.com/cjcodell1/tint/machine/turing/ntm"
+	"github.com/cjcodell1/tint/machine/turing/ways/one"
+	"github.com/cjcodell1/tint/validate"
+)
+
+type validateT struct {
+	m      machine.Machine
+	name   string
+	expect string
+}
+
+var validateTests []validateT
+
+func TestValidate(t *testing.T) {
+	for _, tc := range validateTests {
+		problems, err := validate.Validate(tc.m.(machine.Describer))
+		if err != nil {
+			t.Errorf("Validate(%s) == %s", tc.name, err)
+			continue
+		}
+		lines := []string{}
+		for _, p := range problems {
+			lines = append(lines, p.String())
+		}
+		if got := strings.Join(lines, "\n"); got != tc.expect {
+			t.Errorf("Validate(%s) ==\n%s\n!=\n%s", tc.name, got, tc.expect)
+		}
+	}
+}
+
+var goodDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"even", "a", "odd"},
+		{"even", "b", "even"},
+		{"odd", "a", "even"},
+		{"odd", "b", "odd"},
+	},
+	"even",
+	[]string{"even"})
+
+var typoDFA, _ = dfa.MakeDFA(
+	[][]string{
+		{"even", "a", "odd"},
+		{"even", "b", "even"},
+		{"odd", "a", "evne"},
+		{"odd", "b", "odd"},
+		{"odd", "b", "even"},
+		{"even", "b", "even"},
+		{"lost", "a", "even"},
+	},
+	"even",
+	[]string{"even", "done"})
+
+var duplicateNFA, _ = nfa.MakeNFA(
+	[][]string{
+		{"q0", "a", "q0"},
+		{"q0", "a", "q1"},
+		{"q0", "a", "q0"},
+	},
+	"q0",
+	[]string{"q1"})
+
+var shadowedTM, _ = one.MakeTuringMachine(
+	[][]string{
+		{"q0", "*", "q0", "*", turing.Right},
+		{"q0", "a", "q1", "x", turing.Right},
+		{"q0", turing.Blank, "yes", turing.Blank, turing.Left},
+		{"q1", "*", "no", "*", turing.Right},
+		{"yes", "a", "no", "a", turing.Right},
+	},
+	"q0",
+	"yes",
+	"no")
+
+var wildcardTM, _ = one.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", "q1", "x", turing.Right},
+		{"*", "*", "no", "*", turing.Right},
+		{"q1", "a", "stuck", "a", turing.Right},
+	},
+	"q0",
+	"yes",
+	"no")
+
+var conflictNTM, _ = ntm.MakeTuringMachine(
+	[][]string{
+		{"q0", "a", "q0", "a", turing.Right},
+		{"q0", "a", "yes", "a", turing.Right},
+		{"q0", "*", "no", "*", turing.Right},
+	},
+	"q0",
+	"yes",
+	"no")
+
+var conflictMulti, _ = multi.MakeTuringMachine(
+	[][]string{
+		{"q0", "*", "b", "yes", "a", "b", turing.Right, turing.Stay},
+		{"q0", "a", "b", "no", "a", "b", turing.Right, turing.Stay},
+		{"q0", "a", "a", "no", "a", "b", turing.Right, turing.Stay},
+	},
+	2,
+	"q0",
+	"yes",
+	"no")
+
+func init() {
+	validateTests = []validateT{
+		{goodDFA, "goodDFA", ""},
+		{typoDFA, "typoDFA", strings.Join([]string{
+			"warning: the accept state done is not in any transition, it may be misspelled",
+			"warning: the state lost cannot be reached from the start state",
+			"warning: the state done cannot be reached from the start state",
+			"warning: the state evne has no transition for a, b, so the machine errors if it reads it there",
+			"error: transition 5: it reads the same as transition 4, which is followed instead, so this transition is never followed",
+			"warning: transition 6: it is the same as transition 2",
+		}, "\n")},
+		{duplicateNFA, "duplicateNFA", "warning: transition 3: it is the same as transition 1"},
+		{shadowedTM, "shadowedTM", strings.Join([]string{
+			"error: transition 2: the wildcard in transition 1 matches everything this transition does, so this transition is never followed",
+			"error: transition 3: the wildcard in transition 1 matches everything this transition does, so this transition is never followed",
+			"warning: transition 5: yes halts the machine, so this transition is never followed",
+		}, "\n")},
+		{wildcardTM, "wildcardTM", strings.Join([]string{
+			"warning: the accept state yes is not in any transition, it may be misspelled",
+			"warning: the state yes cannot be reached from the start state",
+			"error: transition 3: the wildcard in transition 2 matches everything this transition does, so this transition is never followed",
+		}, "\n")},
+		{conflictNTM, "conflictNTM", ""},
+		{conflictMulti, "conflictMulti", strings.Join([]string{
+			"error: transition 2: the wildcard in transition 1 matches everything this transition does, so this transition is never followed",
+		}, "\n")},
+	}
+}